@@ -4644,7 +4644,11 @@ type ListMatchesRequest struct {
 	// Maximum user count.
 	MaxSize *wrapperspb.Int32Value `protobuf:"bytes,5,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
 	// Arbitrary label query.
-	Query         *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	Query *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	// Minimum spectator count.
+	MinSpectators *wrapperspb.Int32Value `protobuf:"bytes,7,opt,name=min_spectators,json=minSpectators,proto3" json:"min_spectators,omitempty"`
+	// Maximum spectator count.
+	MaxSpectators *wrapperspb.Int32Value `protobuf:"bytes,8,opt,name=max_spectators,json=maxSpectators,proto3" json:"max_spectators,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -4721,6 +4725,20 @@ func (x *ListMatchesRequest) GetQuery() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *ListMatchesRequest) GetMinSpectators() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MinSpectators
+	}
+	return nil
+}
+
+func (x *ListMatchesRequest) GetMaxSpectators() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MaxSpectators
+	}
+	return nil
+}
+
 // Get a list of unexpired notifications.
 type ListNotificationsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -5246,9 +5264,11 @@ type Match struct {
 	// Tick Rate
 	TickRate int32 `protobuf:"varint,5,opt,name=tick_rate,json=tickRate,proto3" json:"tick_rate,omitempty"`
 	// Handler name
-	HandlerName   string `protobuf:"bytes,6,opt,name=handler_name,json=handlerName,proto3" json:"handler_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	HandlerName string `protobuf:"bytes,6,opt,name=handler_name,json=handlerName,proto3" json:"handler_name,omitempty"`
+	// Current number of spectators in the match.
+	SpectatorCount int32 `protobuf:"varint,7,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Match) Reset() {
@@ -5323,6 +5343,13 @@ func (x *Match) GetHandlerName() string {
 	return ""
 }
 
+func (x *Match) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
 // A list of realtime matches.
 type MatchList struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -5374,6 +5401,7 @@ type MatchmakerCompletionStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	CompleteTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=complete_time,json=completeTime,proto3" json:"complete_time,omitempty"`
+	Stage         int32                  `protobuf:"varint,3,opt,name=stage,proto3" json:"stage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -5422,12 +5450,20 @@ func (x *MatchmakerCompletionStats) GetCompleteTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *MatchmakerCompletionStats) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
 // Matchmaker stats
 type MatchmakerStats struct {
 	state                  protoimpl.MessageState       `protogen:"open.v1"`
 	TicketCount            int32                        `protobuf:"varint,1,opt,name=ticket_count,json=ticketCount,proto3" json:"ticket_count,omitempty"`
 	OldestTicketCreateTime *timestamppb.Timestamp       `protobuf:"bytes,2,opt,name=oldest_ticket_create_time,json=oldestTicketCreateTime,proto3" json:"oldest_ticket_create_time,omitempty"`
 	Completions            []*MatchmakerCompletionStats `protobuf:"bytes,3,rep,name=completions,proto3" json:"completions,omitempty"`
+	ExpiredTicketCount     int32                        `protobuf:"varint,4,opt,name=expired_ticket_count,json=expiredTicketCount,proto3" json:"expired_ticket_count,omitempty"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -5483,6 +5519,13 @@ func (x *MatchmakerStats) GetCompletions() []*MatchmakerCompletionStats {
 	return nil
 }
 
+func (x *MatchmakerStats) GetExpiredTicketCount() int32 {
+	if x != nil {
+		return x.ExpiredTicketCount
+	}
+	return 0
+}
+
 // A notification in the server.
 type Notification struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -9173,14 +9216,16 @@ const file_api_proto_rawDesc = "" +
 	"\towner_ids\x18\x02 \x03(\tR\bownerIds\x121\n" +
 	"\x05limit\x18\x03 \x01(\v2\x1b.google.protobuf.Int32ValueR\x05limit\x12\x16\n" +
 	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x123\n" +
-	"\x06expiry\x18\x05 \x01(\v2\x1b.google.protobuf.Int64ValueR\x06expiry\"\xe1\x02\n" +
+	"\x06expiry\x18\x05 \x01(\v2\x1b.google.protobuf.Int64ValueR\x06expiry\"\xe9\x03\n" +
 	"\x12ListMatchesRequest\x121\n" +
 	"\x05limit\x18\x01 \x01(\v2\x1b.google.protobuf.Int32ValueR\x05limit\x12@\n" +
 	"\rauthoritative\x18\x02 \x01(\v2\x1a.google.protobuf.BoolValueR\rauthoritative\x122\n" +
 	"\x05label\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\x05label\x126\n" +
 	"\bmin_size\x18\x04 \x01(\v2\x1b.google.protobuf.Int32ValueR\aminSize\x126\n" +
 	"\bmax_size\x18\x05 \x01(\v2\x1b.google.protobuf.Int32ValueR\amaxSize\x122\n" +
-	"\x05query\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\x05query\"x\n" +
+	"\x05query\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\x05query\x12B\n" +
+	"\x0emin_spectators\x18\a \x01(\v2\x1b.google.protobuf.Int32ValueR\rminSpectators\x12B\n" +
+	"\x0emax_spectators\x18\b \x01(\v2\x1b.google.protobuf.Int32ValueR\rmaxSpectators\"x\n" +
 	"\x18ListNotificationsRequest\x121\n" +
 	"\x05limit\x18\x01 \x01(\v2\x1b.google.protobuf.Int32ValueR\x05limit\x12)\n" +
 	"\x10cacheable_cursor\x18\x02 \x01(\tR\x0fcacheableCursor\"\x9f\x01\n" +
@@ -9218,24 +9263,27 @@ const file_api_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
 	"\x05limit\x18\x02 \x01(\v2\x1b.google.protobuf.Int32ValueR\x05limit\x121\n" +
 	"\x05state\x18\x03 \x01(\v2\x1b.google.protobuf.Int32ValueR\x05state\x12\x16\n" +
-	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xd0\x01\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xf9\x01\n" +
 	"\x05Match\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12$\n" +
 	"\rauthoritative\x18\x02 \x01(\bR\rauthoritative\x122\n" +
 	"\x05label\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\x05label\x12\x12\n" +
 	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x1b\n" +
 	"\ttick_rate\x18\x05 \x01(\x05R\btickRate\x12!\n" +
-	"\fhandler_name\x18\x06 \x01(\tR\vhandlerName\"8\n" +
+	"\fhandler_name\x18\x06 \x01(\tR\vhandlerName\x12'\n" +
+	"\x0fspectator_count\x18\a \x01(\x05R\x0espectatorCount\"8\n" +
 	"\tMatchList\x12+\n" +
-	"\amatches\x18\x01 \x03(\v2\x11.nakama.api.MatchR\amatches\"\x99\x01\n" +
+	"\amatches\x18\x01 \x03(\v2\x11.nakama.api.MatchR\amatches\"\xaf\x01\n" +
 	"\x19MatchmakerCompletionStats\x12;\n" +
 	"\vcreate_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"createTime\x12?\n" +
-	"\rcomplete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcompleteTime\"\xd4\x01\n" +
+	"\rcomplete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcompleteTime\x12\x14\n" +
+	"\x05stage\x18\x03 \x01(\x05R\x05stage\"\x86\x02\n" +
 	"\x0fMatchmakerStats\x12!\n" +
 	"\fticket_count\x18\x01 \x01(\x05R\vticketCount\x12U\n" +
 	"\x19oldest_ticket_create_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x16oldestTicketCreateTime\x12G\n" +
-	"\vcompletions\x18\x03 \x03(\v2%.nakama.api.MatchmakerCompletionStatsR\vcompletions\"\xe0\x01\n" +
+	"\vcompletions\x18\x03 \x03(\v2%.nakama.api.MatchmakerCompletionStatsR\vcompletions\x120\n" +
+	"\x14expired_ticket_count\x18\x04 \x01(\x05R\x12expiredTicketCount\"\xe0\x01\n" +
 	"\fNotification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\asubject\x18\x02 \x01(\tR\asubject\x12\x18\n" +
@@ -9786,103 +9834,105 @@ var file_api_proto_depIdxs = []int32{
 	141, // 87: nakama.api.ListMatchesRequest.min_size:type_name -> google.protobuf.Int32Value
 	141, // 88: nakama.api.ListMatchesRequest.max_size:type_name -> google.protobuf.Int32Value
 	142, // 89: nakama.api.ListMatchesRequest.query:type_name -> google.protobuf.StringValue
-	141, // 90: nakama.api.ListNotificationsRequest.limit:type_name -> google.protobuf.Int32Value
-	141, // 91: nakama.api.ListStorageObjectsRequest.limit:type_name -> google.protobuf.Int32Value
-	141, // 92: nakama.api.ListSubscriptionsRequest.limit:type_name -> google.protobuf.Int32Value
-	143, // 93: nakama.api.ListTournamentRecordsAroundOwnerRequest.limit:type_name -> google.protobuf.UInt32Value
-	144, // 94: nakama.api.ListTournamentRecordsAroundOwnerRequest.expiry:type_name -> google.protobuf.Int64Value
-	141, // 95: nakama.api.ListTournamentRecordsRequest.limit:type_name -> google.protobuf.Int32Value
-	144, // 96: nakama.api.ListTournamentRecordsRequest.expiry:type_name -> google.protobuf.Int64Value
-	143, // 97: nakama.api.ListTournamentsRequest.category_start:type_name -> google.protobuf.UInt32Value
-	143, // 98: nakama.api.ListTournamentsRequest.category_end:type_name -> google.protobuf.UInt32Value
-	143, // 99: nakama.api.ListTournamentsRequest.start_time:type_name -> google.protobuf.UInt32Value
-	143, // 100: nakama.api.ListTournamentsRequest.end_time:type_name -> google.protobuf.UInt32Value
-	141, // 101: nakama.api.ListTournamentsRequest.limit:type_name -> google.protobuf.Int32Value
-	141, // 102: nakama.api.ListUserGroupsRequest.limit:type_name -> google.protobuf.Int32Value
-	141, // 103: nakama.api.ListUserGroupsRequest.state:type_name -> google.protobuf.Int32Value
-	142, // 104: nakama.api.Match.label:type_name -> google.protobuf.StringValue
-	78,  // 105: nakama.api.MatchList.matches:type_name -> nakama.api.Match
-	139, // 106: nakama.api.MatchmakerCompletionStats.create_time:type_name -> google.protobuf.Timestamp
-	139, // 107: nakama.api.MatchmakerCompletionStats.complete_time:type_name -> google.protobuf.Timestamp
-	139, // 108: nakama.api.MatchmakerStats.oldest_ticket_create_time:type_name -> google.protobuf.Timestamp
-	80,  // 109: nakama.api.MatchmakerStats.completions:type_name -> nakama.api.MatchmakerCompletionStats
-	139, // 110: nakama.api.Notification.create_time:type_name -> google.protobuf.Timestamp
-	82,  // 111: nakama.api.NotificationList.notifications:type_name -> nakama.api.Notification
-	86,  // 112: nakama.api.ReadStorageObjectsRequest.object_ids:type_name -> nakama.api.ReadStorageObjectId
-	139, // 113: nakama.api.StorageObject.create_time:type_name -> google.protobuf.Timestamp
-	139, // 114: nakama.api.StorageObject.update_time:type_name -> google.protobuf.Timestamp
-	139, // 115: nakama.api.StorageObjectAck.create_time:type_name -> google.protobuf.Timestamp
-	139, // 116: nakama.api.StorageObjectAck.update_time:type_name -> google.protobuf.Timestamp
-	91,  // 117: nakama.api.StorageObjectAcks.acks:type_name -> nakama.api.StorageObjectAck
-	90,  // 118: nakama.api.StorageObjects.objects:type_name -> nakama.api.StorageObject
-	90,  // 119: nakama.api.StorageObjectList.objects:type_name -> nakama.api.StorageObject
-	139, // 120: nakama.api.Tournament.create_time:type_name -> google.protobuf.Timestamp
-	139, // 121: nakama.api.Tournament.start_time:type_name -> google.protobuf.Timestamp
-	139, // 122: nakama.api.Tournament.end_time:type_name -> google.protobuf.Timestamp
-	2,   // 123: nakama.api.Tournament.operator:type_name -> nakama.api.Operator
-	95,  // 124: nakama.api.TournamentList.tournaments:type_name -> nakama.api.Tournament
-	58,  // 125: nakama.api.TournamentRecordList.records:type_name -> nakama.api.LeaderboardRecord
-	58,  // 126: nakama.api.TournamentRecordList.owner_records:type_name -> nakama.api.LeaderboardRecord
-	142, // 127: nakama.api.UpdateAccountRequest.username:type_name -> google.protobuf.StringValue
-	142, // 128: nakama.api.UpdateAccountRequest.display_name:type_name -> google.protobuf.StringValue
-	142, // 129: nakama.api.UpdateAccountRequest.avatar_url:type_name -> google.protobuf.StringValue
-	142, // 130: nakama.api.UpdateAccountRequest.lang_tag:type_name -> google.protobuf.StringValue
-	142, // 131: nakama.api.UpdateAccountRequest.location:type_name -> google.protobuf.StringValue
-	142, // 132: nakama.api.UpdateAccountRequest.timezone:type_name -> google.protobuf.StringValue
-	142, // 133: nakama.api.UpdateGroupRequest.name:type_name -> google.protobuf.StringValue
-	142, // 134: nakama.api.UpdateGroupRequest.description:type_name -> google.protobuf.StringValue
-	142, // 135: nakama.api.UpdateGroupRequest.lang_tag:type_name -> google.protobuf.StringValue
-	142, // 136: nakama.api.UpdateGroupRequest.avatar_url:type_name -> google.protobuf.StringValue
-	140, // 137: nakama.api.UpdateGroupRequest.open:type_name -> google.protobuf.BoolValue
-	139, // 138: nakama.api.User.create_time:type_name -> google.protobuf.Timestamp
-	139, // 139: nakama.api.User.update_time:type_name -> google.protobuf.Timestamp
-	136, // 140: nakama.api.UserGroupList.user_groups:type_name -> nakama.api.UserGroupList.UserGroup
-	100, // 141: nakama.api.Users.users:type_name -> nakama.api.User
-	140, // 142: nakama.api.ValidatePurchaseAppleRequest.persist:type_name -> google.protobuf.BoolValue
-	140, // 143: nakama.api.ValidateSubscriptionAppleRequest.persist:type_name -> google.protobuf.BoolValue
-	140, // 144: nakama.api.ValidatePurchaseGoogleRequest.persist:type_name -> google.protobuf.BoolValue
-	140, // 145: nakama.api.ValidateSubscriptionGoogleRequest.persist:type_name -> google.protobuf.BoolValue
-	140, // 146: nakama.api.ValidatePurchaseHuaweiRequest.persist:type_name -> google.protobuf.BoolValue
-	140, // 147: nakama.api.ValidatePurchaseFacebookInstantRequest.persist:type_name -> google.protobuf.BoolValue
-	0,   // 148: nakama.api.ValidatedPurchase.store:type_name -> nakama.api.StoreProvider
-	139, // 149: nakama.api.ValidatedPurchase.purchase_time:type_name -> google.protobuf.Timestamp
-	139, // 150: nakama.api.ValidatedPurchase.create_time:type_name -> google.protobuf.Timestamp
-	139, // 151: nakama.api.ValidatedPurchase.update_time:type_name -> google.protobuf.Timestamp
-	139, // 152: nakama.api.ValidatedPurchase.refund_time:type_name -> google.protobuf.Timestamp
-	1,   // 153: nakama.api.ValidatedPurchase.environment:type_name -> nakama.api.StoreEnvironment
-	109, // 154: nakama.api.ValidatePurchaseResponse.validated_purchases:type_name -> nakama.api.ValidatedPurchase
-	112, // 155: nakama.api.ValidateSubscriptionResponse.validated_subscription:type_name -> nakama.api.ValidatedSubscription
-	0,   // 156: nakama.api.ValidatedSubscription.store:type_name -> nakama.api.StoreProvider
-	139, // 157: nakama.api.ValidatedSubscription.purchase_time:type_name -> google.protobuf.Timestamp
-	139, // 158: nakama.api.ValidatedSubscription.create_time:type_name -> google.protobuf.Timestamp
-	139, // 159: nakama.api.ValidatedSubscription.update_time:type_name -> google.protobuf.Timestamp
-	1,   // 160: nakama.api.ValidatedSubscription.environment:type_name -> nakama.api.StoreEnvironment
-	139, // 161: nakama.api.ValidatedSubscription.expiry_time:type_name -> google.protobuf.Timestamp
-	139, // 162: nakama.api.ValidatedSubscription.refund_time:type_name -> google.protobuf.Timestamp
-	109, // 163: nakama.api.PurchaseList.validated_purchases:type_name -> nakama.api.ValidatedPurchase
-	112, // 164: nakama.api.SubscriptionList.validated_subscriptions:type_name -> nakama.api.ValidatedSubscription
-	137, // 165: nakama.api.WriteLeaderboardRecordRequest.record:type_name -> nakama.api.WriteLeaderboardRecordRequest.LeaderboardRecordWrite
-	141, // 166: nakama.api.WriteStorageObject.permission_read:type_name -> google.protobuf.Int32Value
-	141, // 167: nakama.api.WriteStorageObject.permission_write:type_name -> google.protobuf.Int32Value
-	116, // 168: nakama.api.WriteStorageObjectsRequest.objects:type_name -> nakama.api.WriteStorageObject
-	138, // 169: nakama.api.WriteTournamentRecordRequest.record:type_name -> nakama.api.WriteTournamentRecordRequest.TournamentRecordWrite
-	141, // 170: nakama.api.ListPartiesRequest.limit:type_name -> google.protobuf.Int32Value
-	140, // 171: nakama.api.ListPartiesRequest.open:type_name -> google.protobuf.BoolValue
-	142, // 172: nakama.api.ListPartiesRequest.query:type_name -> google.protobuf.StringValue
-	142, // 173: nakama.api.ListPartiesRequest.cursor:type_name -> google.protobuf.StringValue
-	120, // 174: nakama.api.PartyList.parties:type_name -> nakama.api.Party
-	100, // 175: nakama.api.FriendsOfFriendsList.FriendOfFriend.user:type_name -> nakama.api.User
-	100, // 176: nakama.api.GroupUserList.GroupUser.user:type_name -> nakama.api.User
-	141, // 177: nakama.api.GroupUserList.GroupUser.state:type_name -> google.protobuf.Int32Value
-	48,  // 178: nakama.api.UserGroupList.UserGroup.group:type_name -> nakama.api.Group
-	141, // 179: nakama.api.UserGroupList.UserGroup.state:type_name -> google.protobuf.Int32Value
-	2,   // 180: nakama.api.WriteLeaderboardRecordRequest.LeaderboardRecordWrite.operator:type_name -> nakama.api.Operator
-	2,   // 181: nakama.api.WriteTournamentRecordRequest.TournamentRecordWrite.operator:type_name -> nakama.api.Operator
-	182, // [182:182] is the sub-list for method output_type
-	182, // [182:182] is the sub-list for method input_type
-	182, // [182:182] is the sub-list for extension type_name
-	182, // [182:182] is the sub-list for extension extendee
-	0,   // [0:182] is the sub-list for field type_name
+	141, // 90: nakama.api.ListMatchesRequest.min_spectators:type_name -> google.protobuf.Int32Value
+	141, // 91: nakama.api.ListMatchesRequest.max_spectators:type_name -> google.protobuf.Int32Value
+	141, // 92: nakama.api.ListNotificationsRequest.limit:type_name -> google.protobuf.Int32Value
+	141, // 93: nakama.api.ListStorageObjectsRequest.limit:type_name -> google.protobuf.Int32Value
+	141, // 94: nakama.api.ListSubscriptionsRequest.limit:type_name -> google.protobuf.Int32Value
+	143, // 95: nakama.api.ListTournamentRecordsAroundOwnerRequest.limit:type_name -> google.protobuf.UInt32Value
+	144, // 96: nakama.api.ListTournamentRecordsAroundOwnerRequest.expiry:type_name -> google.protobuf.Int64Value
+	141, // 97: nakama.api.ListTournamentRecordsRequest.limit:type_name -> google.protobuf.Int32Value
+	144, // 98: nakama.api.ListTournamentRecordsRequest.expiry:type_name -> google.protobuf.Int64Value
+	143, // 99: nakama.api.ListTournamentsRequest.category_start:type_name -> google.protobuf.UInt32Value
+	143, // 100: nakama.api.ListTournamentsRequest.category_end:type_name -> google.protobuf.UInt32Value
+	143, // 101: nakama.api.ListTournamentsRequest.start_time:type_name -> google.protobuf.UInt32Value
+	143, // 102: nakama.api.ListTournamentsRequest.end_time:type_name -> google.protobuf.UInt32Value
+	141, // 103: nakama.api.ListTournamentsRequest.limit:type_name -> google.protobuf.Int32Value
+	141, // 104: nakama.api.ListUserGroupsRequest.limit:type_name -> google.protobuf.Int32Value
+	141, // 105: nakama.api.ListUserGroupsRequest.state:type_name -> google.protobuf.Int32Value
+	142, // 106: nakama.api.Match.label:type_name -> google.protobuf.StringValue
+	78,  // 107: nakama.api.MatchList.matches:type_name -> nakama.api.Match
+	139, // 108: nakama.api.MatchmakerCompletionStats.create_time:type_name -> google.protobuf.Timestamp
+	139, // 109: nakama.api.MatchmakerCompletionStats.complete_time:type_name -> google.protobuf.Timestamp
+	139, // 110: nakama.api.MatchmakerStats.oldest_ticket_create_time:type_name -> google.protobuf.Timestamp
+	80,  // 111: nakama.api.MatchmakerStats.completions:type_name -> nakama.api.MatchmakerCompletionStats
+	139, // 112: nakama.api.Notification.create_time:type_name -> google.protobuf.Timestamp
+	82,  // 113: nakama.api.NotificationList.notifications:type_name -> nakama.api.Notification
+	86,  // 114: nakama.api.ReadStorageObjectsRequest.object_ids:type_name -> nakama.api.ReadStorageObjectId
+	139, // 115: nakama.api.StorageObject.create_time:type_name -> google.protobuf.Timestamp
+	139, // 116: nakama.api.StorageObject.update_time:type_name -> google.protobuf.Timestamp
+	139, // 117: nakama.api.StorageObjectAck.create_time:type_name -> google.protobuf.Timestamp
+	139, // 118: nakama.api.StorageObjectAck.update_time:type_name -> google.protobuf.Timestamp
+	91,  // 119: nakama.api.StorageObjectAcks.acks:type_name -> nakama.api.StorageObjectAck
+	90,  // 120: nakama.api.StorageObjects.objects:type_name -> nakama.api.StorageObject
+	90,  // 121: nakama.api.StorageObjectList.objects:type_name -> nakama.api.StorageObject
+	139, // 122: nakama.api.Tournament.create_time:type_name -> google.protobuf.Timestamp
+	139, // 123: nakama.api.Tournament.start_time:type_name -> google.protobuf.Timestamp
+	139, // 124: nakama.api.Tournament.end_time:type_name -> google.protobuf.Timestamp
+	2,   // 125: nakama.api.Tournament.operator:type_name -> nakama.api.Operator
+	95,  // 126: nakama.api.TournamentList.tournaments:type_name -> nakama.api.Tournament
+	58,  // 127: nakama.api.TournamentRecordList.records:type_name -> nakama.api.LeaderboardRecord
+	58,  // 128: nakama.api.TournamentRecordList.owner_records:type_name -> nakama.api.LeaderboardRecord
+	142, // 129: nakama.api.UpdateAccountRequest.username:type_name -> google.protobuf.StringValue
+	142, // 130: nakama.api.UpdateAccountRequest.display_name:type_name -> google.protobuf.StringValue
+	142, // 131: nakama.api.UpdateAccountRequest.avatar_url:type_name -> google.protobuf.StringValue
+	142, // 132: nakama.api.UpdateAccountRequest.lang_tag:type_name -> google.protobuf.StringValue
+	142, // 133: nakama.api.UpdateAccountRequest.location:type_name -> google.protobuf.StringValue
+	142, // 134: nakama.api.UpdateAccountRequest.timezone:type_name -> google.protobuf.StringValue
+	142, // 135: nakama.api.UpdateGroupRequest.name:type_name -> google.protobuf.StringValue
+	142, // 136: nakama.api.UpdateGroupRequest.description:type_name -> google.protobuf.StringValue
+	142, // 137: nakama.api.UpdateGroupRequest.lang_tag:type_name -> google.protobuf.StringValue
+	142, // 138: nakama.api.UpdateGroupRequest.avatar_url:type_name -> google.protobuf.StringValue
+	140, // 139: nakama.api.UpdateGroupRequest.open:type_name -> google.protobuf.BoolValue
+	139, // 140: nakama.api.User.create_time:type_name -> google.protobuf.Timestamp
+	139, // 141: nakama.api.User.update_time:type_name -> google.protobuf.Timestamp
+	136, // 142: nakama.api.UserGroupList.user_groups:type_name -> nakama.api.UserGroupList.UserGroup
+	100, // 143: nakama.api.Users.users:type_name -> nakama.api.User
+	140, // 144: nakama.api.ValidatePurchaseAppleRequest.persist:type_name -> google.protobuf.BoolValue
+	140, // 145: nakama.api.ValidateSubscriptionAppleRequest.persist:type_name -> google.protobuf.BoolValue
+	140, // 146: nakama.api.ValidatePurchaseGoogleRequest.persist:type_name -> google.protobuf.BoolValue
+	140, // 147: nakama.api.ValidateSubscriptionGoogleRequest.persist:type_name -> google.protobuf.BoolValue
+	140, // 148: nakama.api.ValidatePurchaseHuaweiRequest.persist:type_name -> google.protobuf.BoolValue
+	140, // 149: nakama.api.ValidatePurchaseFacebookInstantRequest.persist:type_name -> google.protobuf.BoolValue
+	0,   // 150: nakama.api.ValidatedPurchase.store:type_name -> nakama.api.StoreProvider
+	139, // 151: nakama.api.ValidatedPurchase.purchase_time:type_name -> google.protobuf.Timestamp
+	139, // 152: nakama.api.ValidatedPurchase.create_time:type_name -> google.protobuf.Timestamp
+	139, // 153: nakama.api.ValidatedPurchase.update_time:type_name -> google.protobuf.Timestamp
+	139, // 154: nakama.api.ValidatedPurchase.refund_time:type_name -> google.protobuf.Timestamp
+	1,   // 155: nakama.api.ValidatedPurchase.environment:type_name -> nakama.api.StoreEnvironment
+	109, // 156: nakama.api.ValidatePurchaseResponse.validated_purchases:type_name -> nakama.api.ValidatedPurchase
+	112, // 157: nakama.api.ValidateSubscriptionResponse.validated_subscription:type_name -> nakama.api.ValidatedSubscription
+	0,   // 158: nakama.api.ValidatedSubscription.store:type_name -> nakama.api.StoreProvider
+	139, // 159: nakama.api.ValidatedSubscription.purchase_time:type_name -> google.protobuf.Timestamp
+	139, // 160: nakama.api.ValidatedSubscription.create_time:type_name -> google.protobuf.Timestamp
+	139, // 161: nakama.api.ValidatedSubscription.update_time:type_name -> google.protobuf.Timestamp
+	1,   // 162: nakama.api.ValidatedSubscription.environment:type_name -> nakama.api.StoreEnvironment
+	139, // 163: nakama.api.ValidatedSubscription.expiry_time:type_name -> google.protobuf.Timestamp
+	139, // 164: nakama.api.ValidatedSubscription.refund_time:type_name -> google.protobuf.Timestamp
+	109, // 165: nakama.api.PurchaseList.validated_purchases:type_name -> nakama.api.ValidatedPurchase
+	112, // 166: nakama.api.SubscriptionList.validated_subscriptions:type_name -> nakama.api.ValidatedSubscription
+	137, // 167: nakama.api.WriteLeaderboardRecordRequest.record:type_name -> nakama.api.WriteLeaderboardRecordRequest.LeaderboardRecordWrite
+	141, // 168: nakama.api.WriteStorageObject.permission_read:type_name -> google.protobuf.Int32Value
+	141, // 169: nakama.api.WriteStorageObject.permission_write:type_name -> google.protobuf.Int32Value
+	116, // 170: nakama.api.WriteStorageObjectsRequest.objects:type_name -> nakama.api.WriteStorageObject
+	138, // 171: nakama.api.WriteTournamentRecordRequest.record:type_name -> nakama.api.WriteTournamentRecordRequest.TournamentRecordWrite
+	141, // 172: nakama.api.ListPartiesRequest.limit:type_name -> google.protobuf.Int32Value
+	140, // 173: nakama.api.ListPartiesRequest.open:type_name -> google.protobuf.BoolValue
+	142, // 174: nakama.api.ListPartiesRequest.query:type_name -> google.protobuf.StringValue
+	142, // 175: nakama.api.ListPartiesRequest.cursor:type_name -> google.protobuf.StringValue
+	120, // 176: nakama.api.PartyList.parties:type_name -> nakama.api.Party
+	100, // 177: nakama.api.FriendsOfFriendsList.FriendOfFriend.user:type_name -> nakama.api.User
+	100, // 178: nakama.api.GroupUserList.GroupUser.user:type_name -> nakama.api.User
+	141, // 179: nakama.api.GroupUserList.GroupUser.state:type_name -> google.protobuf.Int32Value
+	48,  // 180: nakama.api.UserGroupList.UserGroup.group:type_name -> nakama.api.Group
+	141, // 181: nakama.api.UserGroupList.UserGroup.state:type_name -> google.protobuf.Int32Value
+	2,   // 182: nakama.api.WriteLeaderboardRecordRequest.LeaderboardRecordWrite.operator:type_name -> nakama.api.Operator
+	2,   // 183: nakama.api.WriteTournamentRecordRequest.TournamentRecordWrite.operator:type_name -> nakama.api.Operator
+	184, // [184:184] is the sub-list for method output_type
+	184, // [184:184] is the sub-list for method input_type
+	184, // [184:184] is the sub-list for extension type_name
+	184, // [184:184] is the sub-list for extension extendee
+	0,   // [0:184] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
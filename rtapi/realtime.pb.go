@@ -1787,9 +1787,11 @@ type Match struct {
 	// The users currently in the match.
 	Presences []*UserPresence `protobuf:"bytes,5,rep,name=presences,proto3" json:"presences,omitempty"`
 	// A reference to the current user's presence in the match.
-	Self          *UserPresence `protobuf:"bytes,6,opt,name=self,proto3" json:"self,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Self *UserPresence `protobuf:"bytes,6,opt,name=self,proto3" json:"self,omitempty"`
+	// The number of spectators currently in the match.
+	SpectatorCount int32 `protobuf:"varint,7,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Match) Reset() {
@@ -1864,6 +1866,13 @@ func (x *Match) GetSelf() *UserPresence {
 	return nil
 }
 
+func (x *Match) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
 // Create a new realtime match.
 type MatchCreate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1922,7 +1931,13 @@ type MatchData struct {
 	// Data payload, if any.
 	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	// True if this data was delivered reliably, false otherwise.
-	Reliable      bool `protobuf:"varint,5,opt,name=reliable,proto3" json:"reliable,omitempty"`
+	Reliable bool `protobuf:"varint,5,opt,name=reliable,proto3" json:"reliable,omitempty"`
+	// The sequence number the sender gave this data, if any.
+	Sequence int64 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The last sequence number from the receiving client that the match has processed, if any.
+	Ack int64 `protobuf:"varint,7,opt,name=ack,proto3" json:"ack,omitempty"`
+	// The match tick this data was sent on, for authoritative matches.
+	Tick          int64 `protobuf:"varint,8,opt,name=tick,proto3" json:"tick,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1992,6 +2007,27 @@ func (x *MatchData) GetReliable() bool {
 	return false
 }
 
+func (x *MatchData) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MatchData) GetAck() int64 {
+	if x != nil {
+		return x.Ack
+	}
+	return 0
+}
+
+func (x *MatchData) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
 // Send realtime match data to the server.
 type MatchDataSend struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2004,7 +2040,11 @@ type MatchDataSend struct {
 	// List of presences in the match to deliver to, if filtering is required. Otherwise deliver to everyone in the match.
 	Presences []*UserPresence `protobuf:"bytes,4,rep,name=presences,proto3" json:"presences,omitempty"`
 	// True if the data should be sent reliably, false otherwise.
-	Reliable      bool `protobuf:"varint,5,opt,name=reliable,proto3" json:"reliable,omitempty"`
+	Reliable bool `protobuf:"varint,5,opt,name=reliable,proto3" json:"reliable,omitempty"`
+	// A client-assigned sequence number for this data, increasing with each message the client sends to the match, if any.
+	Sequence int64 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The last match tick the client has processed, if any.
+	Ack           int64 `protobuf:"varint,7,opt,name=ack,proto3" json:"ack,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2074,6 +2114,20 @@ func (x *MatchDataSend) GetReliable() bool {
 	return false
 }
 
+func (x *MatchDataSend) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MatchDataSend) GetAck() int64 {
+	if x != nil {
+		return x.Ack
+	}
+	return 0
+}
+
 // Join an existing realtime match.
 type MatchJoin struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2083,7 +2137,9 @@ type MatchJoin struct {
 	//	*MatchJoin_Token
 	Id isMatchJoin_Id `protobuf_oneof:"id"`
 	// An optional set of key-value metadata pairs to be passed to the match handler, if any.
-	Metadata      map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Join as a spectator, receiving broadcasts without being able to send match data or counting towards the match size.
+	Spectator     bool `protobuf:"varint,4,opt,name=spectator,proto3" json:"spectator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2150,6 +2206,13 @@ func (x *MatchJoin) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *MatchJoin) GetSpectator() bool {
+	if x != nil {
+		return x.Spectator
+	}
+	return false
+}
+
 type isMatchJoin_Id interface {
 	isMatchJoin_Id()
 }
@@ -2293,6 +2356,12 @@ type MatchmakerAdd struct {
 	NumericProperties map[string]float64 `protobuf:"bytes,5,rep,name=numeric_properties,json=numericProperties,proto3" json:"numeric_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	// Optional multiple of the count that must be satisfied.
 	CountMultiple *wrapperspb.Int32Value `protobuf:"bytes,6,opt,name=count_multiple,json=countMultiple,proto3" json:"count_multiple,omitempty"`
+	// Optional filter queries that replace the query as the ticket waits, in increasing order of wait time.
+	Stages []*MatchmakerAdd_QueryStage `protobuf:"bytes,7,rep,name=stages,proto3" json:"stages,omitempty"`
+	// Optional seconds after which the ticket is removed if it has not been matched.
+	TtlSec int32 `protobuf:"varint,8,opt,name=ttl_sec,json=ttlSec,proto3" json:"ttl_sec,omitempty"`
+	// Optional latency in milliseconds from the user to each region, keyed by region.
+	Latencies     map[string]float32 `protobuf:"bytes,9,rep,name=latencies,proto3" json:"latencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2369,6 +2438,27 @@ func (x *MatchmakerAdd) GetCountMultiple() *wrapperspb.Int32Value {
 	return nil
 }
 
+func (x *MatchmakerAdd) GetStages() []*MatchmakerAdd_QueryStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *MatchmakerAdd) GetTtlSec() int32 {
+	if x != nil {
+		return x.TtlSec
+	}
+	return 0
+}
+
+func (x *MatchmakerAdd) GetLatencies() map[string]float32 {
+	if x != nil {
+		return x.Latencies
+	}
+	return nil
+}
+
 // A successful matchmaking result.
 type MatchmakerMatched struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2384,7 +2474,9 @@ type MatchmakerMatched struct {
 	// The users that have been matched together, and information about their matchmaking data.
 	Users []*MatchmakerMatched_MatchmakerUser `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
 	// A reference to the current user and their properties.
-	Self          *MatchmakerMatched_MatchmakerUser `protobuf:"bytes,5,opt,name=self,proto3" json:"self,omitempty"`
+	Self *MatchmakerMatched_MatchmakerUser `protobuf:"bytes,5,opt,name=self,proto3" json:"self,omitempty"`
+	// The region the matched users share under the latency threshold, if they gave latencies.
+	Region        string `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2465,6 +2557,13 @@ func (x *MatchmakerMatched) GetSelf() *MatchmakerMatched_MatchmakerUser {
 	return nil
 }
 
+func (x *MatchmakerMatched) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
 type isMatchmakerMatched_Id interface {
 	isMatchmakerMatched_Id()
 }
@@ -3352,6 +3451,12 @@ type PartyMatchmakerAdd struct {
 	NumericProperties map[string]float64 `protobuf:"bytes,6,rep,name=numeric_properties,json=numericProperties,proto3" json:"numeric_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	// Optional multiple of the count that must be satisfied.
 	CountMultiple *wrapperspb.Int32Value `protobuf:"bytes,7,opt,name=count_multiple,json=countMultiple,proto3" json:"count_multiple,omitempty"`
+	// Optional filter queries that replace the query as the ticket waits, in increasing order of wait time.
+	Stages []*MatchmakerAdd_QueryStage `protobuf:"bytes,8,rep,name=stages,proto3" json:"stages,omitempty"`
+	// Optional seconds after which the ticket is removed if it has not been matched.
+	TtlSec int32 `protobuf:"varint,9,opt,name=ttl_sec,json=ttlSec,proto3" json:"ttl_sec,omitempty"`
+	// Optional latency in milliseconds from the user to each region, keyed by region.
+	Latencies     map[string]float32 `protobuf:"bytes,10,rep,name=latencies,proto3" json:"latencies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -3435,6 +3540,27 @@ func (x *PartyMatchmakerAdd) GetCountMultiple() *wrapperspb.Int32Value {
 	return nil
 }
 
+func (x *PartyMatchmakerAdd) GetStages() []*MatchmakerAdd_QueryStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *PartyMatchmakerAdd) GetTtlSec() int32 {
+	if x != nil {
+		return x.TtlSec
+	}
+	return 0
+}
+
+func (x *PartyMatchmakerAdd) GetLatencies() map[string]float32 {
+	if x != nil {
+		return x.Latencies
+	}
+	return nil
+}
+
 // Cancel a party matchmaking process using a ticket.
 type PartyMatchmakerRemove struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4360,6 +4486,61 @@ func (x *UserPresence) GetStatus() *wrapperspb.StringValue {
 	return nil
 }
 
+// A filter query that replaces the ticket query once the ticket has waited long enough.
+type MatchmakerAdd_QueryStage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter query used to identify suitable users from this stage.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Seconds since the ticket was added after which this stage applies.
+	AfterSec      int32 `protobuf:"varint,2,opt,name=after_sec,json=afterSec,proto3" json:"after_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchmakerAdd_QueryStage) Reset() {
+	*x = MatchmakerAdd_QueryStage{}
+	mi := &file_realtime_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchmakerAdd_QueryStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchmakerAdd_QueryStage) ProtoMessage() {}
+
+func (x *MatchmakerAdd_QueryStage) ProtoReflect() protoreflect.Message {
+	mi := &file_realtime_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchmakerAdd_QueryStage.ProtoReflect.Descriptor instead.
+func (*MatchmakerAdd_QueryStage) Descriptor() ([]byte, []int) {
+	return file_realtime_proto_rawDescGZIP(), []int{17, 3}
+}
+
+func (x *MatchmakerAdd_QueryStage) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *MatchmakerAdd_QueryStage) GetAfterSec() int32 {
+	if x != nil {
+		return x.AfterSec
+	}
+	return 0
+}
+
 type MatchmakerMatched_MatchmakerUser struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// User info.
@@ -4376,7 +4557,7 @@ type MatchmakerMatched_MatchmakerUser struct {
 
 func (x *MatchmakerMatched_MatchmakerUser) Reset() {
 	*x = MatchmakerMatched_MatchmakerUser{}
-	mi := &file_realtime_proto_msgTypes[55]
+	mi := &file_realtime_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4388,7 +4569,7 @@ func (x *MatchmakerMatched_MatchmakerUser) String() string {
 func (*MatchmakerMatched_MatchmakerUser) ProtoMessage() {}
 
 func (x *MatchmakerMatched_MatchmakerUser) ProtoReflect() protoreflect.Message {
-	mi := &file_realtime_proto_msgTypes[55]
+	mi := &file_realtime_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4578,32 +4759,39 @@ const file_realtime_proto_rawDesc = "" +
 	"\x0fMATCH_NOT_FOUND\x10\x04\x12\x17\n" +
 	"\x13MATCH_JOIN_REJECTED\x10\x05\x12\x1e\n" +
 	"\x1aRUNTIME_FUNCTION_NOT_FOUND\x10\x06\x12\x1e\n" +
-	"\x1aRUNTIME_FUNCTION_EXCEPTION\x10\a\"\x80\x02\n" +
+	"\x1aRUNTIME_FUNCTION_EXCEPTION\x10\a\"\xa9\x02\n" +
 	"\x05Match\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12$\n" +
 	"\rauthoritative\x18\x02 \x01(\bR\rauthoritative\x122\n" +
 	"\x05label\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\x05label\x12\x12\n" +
 	"\x04size\x18\x04 \x01(\x05R\x04size\x12;\n" +
 	"\tpresences\x18\x05 \x03(\v2\x1d.nakama.realtime.UserPresenceR\tpresences\x121\n" +
-	"\x04self\x18\x06 \x01(\v2\x1d.nakama.realtime.UserPresenceR\x04self\"!\n" +
+	"\x04self\x18\x06 \x01(\v2\x1d.nakama.realtime.UserPresenceR\x04self\x12'\n" +
+	"\x0fspectator_count\x18\a \x01(\x05R\x0espectatorCount\"!\n" +
 	"\vMatchCreate\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\xaa\x01\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xec\x01\n" +
 	"\tMatchData\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x129\n" +
 	"\bpresence\x18\x02 \x01(\v2\x1d.nakama.realtime.UserPresenceR\bpresence\x12\x17\n" +
 	"\aop_code\x18\x03 \x01(\x03R\x06opCode\x12\x12\n" +
 	"\x04data\x18\x04 \x01(\fR\x04data\x12\x1a\n" +
-	"\breliable\x18\x05 \x01(\bR\breliable\"\xb0\x01\n" +
+	"\breliable\x18\x05 \x01(\bR\breliable\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence\x12\x10\n" +
+	"\x03ack\x18\a \x01(\x03R\x03ack\x12\x12\n" +
+	"\x04tick\x18\b \x01(\x03R\x04tick\"\xde\x01\n" +
 	"\rMatchDataSend\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x17\n" +
 	"\aop_code\x18\x02 \x01(\x03R\x06opCode\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12;\n" +
 	"\tpresences\x18\x04 \x03(\v2\x1d.nakama.realtime.UserPresenceR\tpresences\x12\x1a\n" +
-	"\breliable\x18\x05 \x01(\bR\breliable\"\xc9\x01\n" +
+	"\breliable\x18\x05 \x01(\bR\breliable\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence\x12\x10\n" +
+	"\x03ack\x18\a \x01(\x03R\x03ack\"\xe7\x01\n" +
 	"\tMatchJoin\x12\x1b\n" +
 	"\bmatch_id\x18\x01 \x01(\tH\x00R\amatchId\x12\x16\n" +
 	"\x05token\x18\x02 \x01(\tH\x00R\x05token\x12D\n" +
-	"\bmetadata\x18\x03 \x03(\v2(.nakama.realtime.MatchJoin.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x03 \x03(\v2(.nakama.realtime.MatchJoin.MetadataEntryR\bmetadata\x12\x1c\n" +
+	"\tspectator\x18\x04 \x01(\bR\tspectator\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x04\n" +
@@ -4614,26 +4802,37 @@ const file_realtime_proto_rawDesc = "" +
 	"\x12MatchPresenceEvent\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x123\n" +
 	"\x05joins\x18\x02 \x03(\v2\x1d.nakama.realtime.UserPresenceR\x05joins\x125\n" +
-	"\x06leaves\x18\x03 \x03(\v2\x1d.nakama.realtime.UserPresenceR\x06leaves\"\xf7\x03\n" +
+	"\x06leaves\x18\x03 \x03(\v2\x1d.nakama.realtime.UserPresenceR\x06leaves\"\x9f\x06\n" +
 	"\rMatchmakerAdd\x12\x1b\n" +
 	"\tmin_count\x18\x01 \x01(\x05R\bminCount\x12\x1b\n" +
 	"\tmax_count\x18\x02 \x01(\x05R\bmaxCount\x12\x14\n" +
 	"\x05query\x18\x03 \x01(\tR\x05query\x12a\n" +
 	"\x11string_properties\x18\x04 \x03(\v24.nakama.realtime.MatchmakerAdd.StringPropertiesEntryR\x10stringProperties\x12d\n" +
 	"\x12numeric_properties\x18\x05 \x03(\v25.nakama.realtime.MatchmakerAdd.NumericPropertiesEntryR\x11numericProperties\x12B\n" +
-	"\x0ecount_multiple\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueR\rcountMultiple\x1aC\n" +
+	"\x0ecount_multiple\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueR\rcountMultiple\x12A\n" +
+	"\x06stages\x18\a \x03(\v2).nakama.realtime.MatchmakerAdd.QueryStageR\x06stages\x12\x17\n" +
+	"\attl_sec\x18\b \x01(\x05R\x06ttlSec\x12K\n" +
+	"\tlatencies\x18\t \x03(\v2-.nakama.realtime.MatchmakerAdd.LatenciesEntryR\tlatencies\x1aC\n" +
 	"\x15StringPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
 	"\x16NumericPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xd9\x05\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a<\n" +
+	"\x0eLatenciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\x1a?\n" +
+	"\n" +
+	"QueryStage\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tafter_sec\x18\x02 \x01(\x05R\bafterSec\"\xf1\x05\n" +
 	"\x11MatchmakerMatched\x12\x16\n" +
 	"\x06ticket\x18\x01 \x01(\tR\x06ticket\x12\x1b\n" +
 	"\bmatch_id\x18\x02 \x01(\tH\x00R\amatchId\x12\x16\n" +
 	"\x05token\x18\x03 \x01(\tH\x00R\x05token\x12G\n" +
 	"\x05users\x18\x04 \x03(\v21.nakama.realtime.MatchmakerMatched.MatchmakerUserR\x05users\x12E\n" +
-	"\x04self\x18\x05 \x01(\v21.nakama.realtime.MatchmakerMatched.MatchmakerUserR\x04self\x1a\xe0\x03\n" +
+	"\x04self\x18\x05 \x01(\v21.nakama.realtime.MatchmakerMatched.MatchmakerUserR\x04self\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x1a\xe0\x03\n" +
 	"\x0eMatchmakerUser\x129\n" +
 	"\bpresence\x18\x01 \x01(\v2\x1d.nakama.realtime.UserPresenceR\bpresence\x12\x19\n" +
 	"\bparty_id\x18\x02 \x01(\tR\apartyId\x12t\n" +
@@ -4695,7 +4894,7 @@ const file_realtime_proto_rawDesc = "" +
 	"\bparty_id\x18\x01 \x01(\tR\apartyId\"j\n" +
 	"\x10PartyJoinRequest\x12\x19\n" +
 	"\bparty_id\x18\x01 \x01(\tR\apartyId\x12;\n" +
-	"\tpresences\x18\x02 \x03(\v2\x1d.nakama.realtime.UserPresenceR\tpresences\"\xa1\x04\n" +
+	"\tpresences\x18\x02 \x03(\v2\x1d.nakama.realtime.UserPresenceR\tpresences\"\x8d\x06\n" +
 	"\x12PartyMatchmakerAdd\x12\x19\n" +
 	"\bparty_id\x18\x01 \x01(\tR\apartyId\x12\x1b\n" +
 	"\tmin_count\x18\x02 \x01(\x05R\bminCount\x12\x1b\n" +
@@ -4703,13 +4902,20 @@ const file_realtime_proto_rawDesc = "" +
 	"\x05query\x18\x04 \x01(\tR\x05query\x12f\n" +
 	"\x11string_properties\x18\x05 \x03(\v29.nakama.realtime.PartyMatchmakerAdd.StringPropertiesEntryR\x10stringProperties\x12i\n" +
 	"\x12numeric_properties\x18\x06 \x03(\v2:.nakama.realtime.PartyMatchmakerAdd.NumericPropertiesEntryR\x11numericProperties\x12B\n" +
-	"\x0ecount_multiple\x18\a \x01(\v2\x1b.google.protobuf.Int32ValueR\rcountMultiple\x1aC\n" +
+	"\x0ecount_multiple\x18\a \x01(\v2\x1b.google.protobuf.Int32ValueR\rcountMultiple\x12A\n" +
+	"\x06stages\x18\b \x03(\v2).nakama.realtime.MatchmakerAdd.QueryStageR\x06stages\x12\x17\n" +
+	"\attl_sec\x18\t \x01(\x05R\x06ttlSec\x12P\n" +
+	"\tlatencies\x18\n" +
+	" \x03(\v22.nakama.realtime.PartyMatchmakerAdd.LatenciesEntryR\tlatencies\x1aC\n" +
 	"\x15StringPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
 	"\x16NumericPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"J\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a<\n" +
+	"\x0eLatenciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"J\n" +
 	"\x15PartyMatchmakerRemove\x12\x19\n" +
 	"\bparty_id\x18\x01 \x01(\tR\apartyId\x12\x16\n" +
 	"\x06ticket\x18\x02 \x01(\tR\x06ticket\"J\n" +
@@ -4782,7 +4988,7 @@ func file_realtime_proto_rawDescGZIP() []byte {
 }
 
 var file_realtime_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_realtime_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_realtime_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_realtime_proto_goTypes = []any{
 	(ChannelJoin_Type)(0),                    // 0: nakama.realtime.ChannelJoin.Type
 	(Error_Code)(0),                          // 1: nakama.realtime.Error.Code
@@ -4841,24 +5047,27 @@ var file_realtime_proto_goTypes = []any{
 	nil,                                      // 54: nakama.realtime.MatchJoin.MetadataEntry
 	nil,                                      // 55: nakama.realtime.MatchmakerAdd.StringPropertiesEntry
 	nil,                                      // 56: nakama.realtime.MatchmakerAdd.NumericPropertiesEntry
-	(*MatchmakerMatched_MatchmakerUser)(nil), // 57: nakama.realtime.MatchmakerMatched.MatchmakerUser
-	nil,                                      // 58: nakama.realtime.MatchmakerMatched.MatchmakerUser.StringPropertiesEntry
-	nil,                                      // 59: nakama.realtime.MatchmakerMatched.MatchmakerUser.NumericPropertiesEntry
-	nil,                                      // 60: nakama.realtime.PartyMatchmakerAdd.StringPropertiesEntry
-	nil,                                      // 61: nakama.realtime.PartyMatchmakerAdd.NumericPropertiesEntry
-	(*api.ChannelMessage)(nil),               // 62: nakama.api.ChannelMessage
-	(*api.Rpc)(nil),                          // 63: nakama.api.Rpc
-	(*wrapperspb.BoolValue)(nil),             // 64: google.protobuf.BoolValue
-	(*wrapperspb.Int32Value)(nil),            // 65: google.protobuf.Int32Value
-	(*timestamppb.Timestamp)(nil),            // 66: google.protobuf.Timestamp
-	(*wrapperspb.StringValue)(nil),           // 67: google.protobuf.StringValue
-	(*api.Notification)(nil),                 // 68: nakama.api.Notification
+	nil,                                      // 57: nakama.realtime.MatchmakerAdd.LatenciesEntry
+	(*MatchmakerAdd_QueryStage)(nil),         // 58: nakama.realtime.MatchmakerAdd.QueryStage
+	(*MatchmakerMatched_MatchmakerUser)(nil), // 59: nakama.realtime.MatchmakerMatched.MatchmakerUser
+	nil,                                      // 60: nakama.realtime.MatchmakerMatched.MatchmakerUser.StringPropertiesEntry
+	nil,                                      // 61: nakama.realtime.MatchmakerMatched.MatchmakerUser.NumericPropertiesEntry
+	nil,                                      // 62: nakama.realtime.PartyMatchmakerAdd.StringPropertiesEntry
+	nil,                                      // 63: nakama.realtime.PartyMatchmakerAdd.NumericPropertiesEntry
+	nil,                                      // 64: nakama.realtime.PartyMatchmakerAdd.LatenciesEntry
+	(*api.ChannelMessage)(nil),               // 65: nakama.api.ChannelMessage
+	(*api.Rpc)(nil),                          // 66: nakama.api.Rpc
+	(*wrapperspb.BoolValue)(nil),             // 67: google.protobuf.BoolValue
+	(*wrapperspb.Int32Value)(nil),            // 68: google.protobuf.Int32Value
+	(*timestamppb.Timestamp)(nil),            // 69: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil),           // 70: google.protobuf.StringValue
+	(*api.Notification)(nil),                 // 71: nakama.api.Notification
 }
 var file_realtime_proto_depIdxs = []int32{
 	3,   // 0: nakama.realtime.Envelope.channel:type_name -> nakama.realtime.Channel
 	4,   // 1: nakama.realtime.Envelope.channel_join:type_name -> nakama.realtime.ChannelJoin
 	5,   // 2: nakama.realtime.Envelope.channel_leave:type_name -> nakama.realtime.ChannelLeave
-	62,  // 3: nakama.realtime.Envelope.channel_message:type_name -> nakama.api.ChannelMessage
+	65,  // 3: nakama.realtime.Envelope.channel_message:type_name -> nakama.api.ChannelMessage
 	6,   // 4: nakama.realtime.Envelope.channel_message_ack:type_name -> nakama.realtime.ChannelMessageAck
 	7,   // 5: nakama.realtime.Envelope.channel_message_send:type_name -> nakama.realtime.ChannelMessageSend
 	8,   // 6: nakama.realtime.Envelope.channel_message_update:type_name -> nakama.realtime.ChannelMessageUpdate
@@ -4877,7 +5086,7 @@ var file_realtime_proto_depIdxs = []int32{
 	21,  // 19: nakama.realtime.Envelope.matchmaker_remove:type_name -> nakama.realtime.MatchmakerRemove
 	22,  // 20: nakama.realtime.Envelope.matchmaker_ticket:type_name -> nakama.realtime.MatchmakerTicket
 	23,  // 21: nakama.realtime.Envelope.notifications:type_name -> nakama.realtime.Notifications
-	63,  // 22: nakama.realtime.Envelope.rpc:type_name -> nakama.api.Rpc
+	66,  // 22: nakama.realtime.Envelope.rpc:type_name -> nakama.api.Rpc
 	44,  // 23: nakama.realtime.Envelope.status:type_name -> nakama.realtime.Status
 	45,  // 24: nakama.realtime.Envelope.status_follow:type_name -> nakama.realtime.StatusFollow
 	46,  // 25: nakama.realtime.Envelope.status_presence_event:type_name -> nakama.realtime.StatusPresenceEvent
@@ -4907,16 +5116,16 @@ var file_realtime_proto_depIdxs = []int32{
 	26,  // 49: nakama.realtime.Envelope.party_update:type_name -> nakama.realtime.PartyUpdate
 	52,  // 50: nakama.realtime.Channel.presences:type_name -> nakama.realtime.UserPresence
 	52,  // 51: nakama.realtime.Channel.self:type_name -> nakama.realtime.UserPresence
-	64,  // 52: nakama.realtime.ChannelJoin.persistence:type_name -> google.protobuf.BoolValue
-	64,  // 53: nakama.realtime.ChannelJoin.hidden:type_name -> google.protobuf.BoolValue
-	65,  // 54: nakama.realtime.ChannelMessageAck.code:type_name -> google.protobuf.Int32Value
-	66,  // 55: nakama.realtime.ChannelMessageAck.create_time:type_name -> google.protobuf.Timestamp
-	66,  // 56: nakama.realtime.ChannelMessageAck.update_time:type_name -> google.protobuf.Timestamp
-	64,  // 57: nakama.realtime.ChannelMessageAck.persistent:type_name -> google.protobuf.BoolValue
+	67,  // 52: nakama.realtime.ChannelJoin.persistence:type_name -> google.protobuf.BoolValue
+	67,  // 53: nakama.realtime.ChannelJoin.hidden:type_name -> google.protobuf.BoolValue
+	68,  // 54: nakama.realtime.ChannelMessageAck.code:type_name -> google.protobuf.Int32Value
+	69,  // 55: nakama.realtime.ChannelMessageAck.create_time:type_name -> google.protobuf.Timestamp
+	69,  // 56: nakama.realtime.ChannelMessageAck.update_time:type_name -> google.protobuf.Timestamp
+	67,  // 57: nakama.realtime.ChannelMessageAck.persistent:type_name -> google.protobuf.BoolValue
 	52,  // 58: nakama.realtime.ChannelPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
 	52,  // 59: nakama.realtime.ChannelPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
 	53,  // 60: nakama.realtime.Error.context:type_name -> nakama.realtime.Error.ContextEntry
-	67,  // 61: nakama.realtime.Match.label:type_name -> google.protobuf.StringValue
+	70,  // 61: nakama.realtime.Match.label:type_name -> google.protobuf.StringValue
 	52,  // 62: nakama.realtime.Match.presences:type_name -> nakama.realtime.UserPresence
 	52,  // 63: nakama.realtime.Match.self:type_name -> nakama.realtime.UserPresence
 	52,  // 64: nakama.realtime.MatchData.presence:type_name -> nakama.realtime.UserPresence
@@ -4926,42 +5135,46 @@ var file_realtime_proto_depIdxs = []int32{
 	52,  // 68: nakama.realtime.MatchPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
 	55,  // 69: nakama.realtime.MatchmakerAdd.string_properties:type_name -> nakama.realtime.MatchmakerAdd.StringPropertiesEntry
 	56,  // 70: nakama.realtime.MatchmakerAdd.numeric_properties:type_name -> nakama.realtime.MatchmakerAdd.NumericPropertiesEntry
-	65,  // 71: nakama.realtime.MatchmakerAdd.count_multiple:type_name -> google.protobuf.Int32Value
-	57,  // 72: nakama.realtime.MatchmakerMatched.users:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser
-	57,  // 73: nakama.realtime.MatchmakerMatched.self:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser
-	68,  // 74: nakama.realtime.Notifications.notifications:type_name -> nakama.api.Notification
-	52,  // 75: nakama.realtime.Party.self:type_name -> nakama.realtime.UserPresence
-	52,  // 76: nakama.realtime.Party.leader:type_name -> nakama.realtime.UserPresence
-	52,  // 77: nakama.realtime.Party.presences:type_name -> nakama.realtime.UserPresence
-	52,  // 78: nakama.realtime.PartyPromote.presence:type_name -> nakama.realtime.UserPresence
-	52,  // 79: nakama.realtime.PartyLeader.presence:type_name -> nakama.realtime.UserPresence
-	52,  // 80: nakama.realtime.PartyAccept.presence:type_name -> nakama.realtime.UserPresence
-	52,  // 81: nakama.realtime.PartyRemove.presence:type_name -> nakama.realtime.UserPresence
-	52,  // 82: nakama.realtime.PartyJoinRequest.presences:type_name -> nakama.realtime.UserPresence
-	60,  // 83: nakama.realtime.PartyMatchmakerAdd.string_properties:type_name -> nakama.realtime.PartyMatchmakerAdd.StringPropertiesEntry
-	61,  // 84: nakama.realtime.PartyMatchmakerAdd.numeric_properties:type_name -> nakama.realtime.PartyMatchmakerAdd.NumericPropertiesEntry
-	65,  // 85: nakama.realtime.PartyMatchmakerAdd.count_multiple:type_name -> google.protobuf.Int32Value
-	52,  // 86: nakama.realtime.PartyData.presence:type_name -> nakama.realtime.UserPresence
-	52,  // 87: nakama.realtime.PartyPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
-	52,  // 88: nakama.realtime.PartyPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
-	52,  // 89: nakama.realtime.Status.presences:type_name -> nakama.realtime.UserPresence
-	52,  // 90: nakama.realtime.StatusPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
-	52,  // 91: nakama.realtime.StatusPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
-	67,  // 92: nakama.realtime.StatusUpdate.status:type_name -> google.protobuf.StringValue
-	49,  // 93: nakama.realtime.StreamData.stream:type_name -> nakama.realtime.Stream
-	52,  // 94: nakama.realtime.StreamData.sender:type_name -> nakama.realtime.UserPresence
-	49,  // 95: nakama.realtime.StreamPresenceEvent.stream:type_name -> nakama.realtime.Stream
-	52,  // 96: nakama.realtime.StreamPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
-	52,  // 97: nakama.realtime.StreamPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
-	67,  // 98: nakama.realtime.UserPresence.status:type_name -> google.protobuf.StringValue
-	52,  // 99: nakama.realtime.MatchmakerMatched.MatchmakerUser.presence:type_name -> nakama.realtime.UserPresence
-	58,  // 100: nakama.realtime.MatchmakerMatched.MatchmakerUser.string_properties:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser.StringPropertiesEntry
-	59,  // 101: nakama.realtime.MatchmakerMatched.MatchmakerUser.numeric_properties:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser.NumericPropertiesEntry
-	102, // [102:102] is the sub-list for method output_type
-	102, // [102:102] is the sub-list for method input_type
-	102, // [102:102] is the sub-list for extension type_name
-	102, // [102:102] is the sub-list for extension extendee
-	0,   // [0:102] is the sub-list for field type_name
+	68,  // 71: nakama.realtime.MatchmakerAdd.count_multiple:type_name -> google.protobuf.Int32Value
+	58,  // 72: nakama.realtime.MatchmakerAdd.stages:type_name -> nakama.realtime.MatchmakerAdd.QueryStage
+	57,  // 73: nakama.realtime.MatchmakerAdd.latencies:type_name -> nakama.realtime.MatchmakerAdd.LatenciesEntry
+	59,  // 74: nakama.realtime.MatchmakerMatched.users:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser
+	59,  // 75: nakama.realtime.MatchmakerMatched.self:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser
+	71,  // 76: nakama.realtime.Notifications.notifications:type_name -> nakama.api.Notification
+	52,  // 77: nakama.realtime.Party.self:type_name -> nakama.realtime.UserPresence
+	52,  // 78: nakama.realtime.Party.leader:type_name -> nakama.realtime.UserPresence
+	52,  // 79: nakama.realtime.Party.presences:type_name -> nakama.realtime.UserPresence
+	52,  // 80: nakama.realtime.PartyPromote.presence:type_name -> nakama.realtime.UserPresence
+	52,  // 81: nakama.realtime.PartyLeader.presence:type_name -> nakama.realtime.UserPresence
+	52,  // 82: nakama.realtime.PartyAccept.presence:type_name -> nakama.realtime.UserPresence
+	52,  // 83: nakama.realtime.PartyRemove.presence:type_name -> nakama.realtime.UserPresence
+	52,  // 84: nakama.realtime.PartyJoinRequest.presences:type_name -> nakama.realtime.UserPresence
+	62,  // 85: nakama.realtime.PartyMatchmakerAdd.string_properties:type_name -> nakama.realtime.PartyMatchmakerAdd.StringPropertiesEntry
+	63,  // 86: nakama.realtime.PartyMatchmakerAdd.numeric_properties:type_name -> nakama.realtime.PartyMatchmakerAdd.NumericPropertiesEntry
+	68,  // 87: nakama.realtime.PartyMatchmakerAdd.count_multiple:type_name -> google.protobuf.Int32Value
+	58,  // 88: nakama.realtime.PartyMatchmakerAdd.stages:type_name -> nakama.realtime.MatchmakerAdd.QueryStage
+	64,  // 89: nakama.realtime.PartyMatchmakerAdd.latencies:type_name -> nakama.realtime.PartyMatchmakerAdd.LatenciesEntry
+	52,  // 90: nakama.realtime.PartyData.presence:type_name -> nakama.realtime.UserPresence
+	52,  // 91: nakama.realtime.PartyPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
+	52,  // 92: nakama.realtime.PartyPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
+	52,  // 93: nakama.realtime.Status.presences:type_name -> nakama.realtime.UserPresence
+	52,  // 94: nakama.realtime.StatusPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
+	52,  // 95: nakama.realtime.StatusPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
+	70,  // 96: nakama.realtime.StatusUpdate.status:type_name -> google.protobuf.StringValue
+	49,  // 97: nakama.realtime.StreamData.stream:type_name -> nakama.realtime.Stream
+	52,  // 98: nakama.realtime.StreamData.sender:type_name -> nakama.realtime.UserPresence
+	49,  // 99: nakama.realtime.StreamPresenceEvent.stream:type_name -> nakama.realtime.Stream
+	52,  // 100: nakama.realtime.StreamPresenceEvent.joins:type_name -> nakama.realtime.UserPresence
+	52,  // 101: nakama.realtime.StreamPresenceEvent.leaves:type_name -> nakama.realtime.UserPresence
+	70,  // 102: nakama.realtime.UserPresence.status:type_name -> google.protobuf.StringValue
+	52,  // 103: nakama.realtime.MatchmakerMatched.MatchmakerUser.presence:type_name -> nakama.realtime.UserPresence
+	60,  // 104: nakama.realtime.MatchmakerMatched.MatchmakerUser.string_properties:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser.StringPropertiesEntry
+	61,  // 105: nakama.realtime.MatchmakerMatched.MatchmakerUser.numeric_properties:type_name -> nakama.realtime.MatchmakerMatched.MatchmakerUser.NumericPropertiesEntry
+	106, // [106:106] is the sub-list for method output_type
+	106, // [106:106] is the sub-list for method input_type
+	106, // [106:106] is the sub-list for extension type_name
+	106, // [106:106] is the sub-list for extension extendee
+	0,   // [0:106] is the sub-list for field type_name
 }
 
 func init() { file_realtime_proto_init() }
@@ -5035,7 +5248,7 @@ func file_realtime_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_realtime_proto_rawDesc), len(file_realtime_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   60,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,240 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+)
+
+// ExecutionMode is the kind of function a runtime context was created for, as set in RUNTIME_CTX_MODE.
+type ExecutionMode int
+
+const (
+	ExecutionModeUnknown ExecutionMode = iota
+	ExecutionModeEvent
+	ExecutionModeRunOnce
+	ExecutionModeRpc
+	ExecutionModeBefore
+	ExecutionModeAfter
+	ExecutionModeMatch
+	ExecutionModeMatchmaker
+	ExecutionModeMatchmakerOverride
+	ExecutionModeMatchmakerProcessor
+	ExecutionModeLeaderboardReset
+	ExecutionModeTournamentReset
+	ExecutionModeTournamentEnd
+	ExecutionModePurchaseNotificationApple
+	ExecutionModeSubscriptionNotificationApple
+	ExecutionModePurchaseNotificationGoogle
+	ExecutionModeSubscriptionNotificationGoogle
+	ExecutionModeStorageIndexFilter
+	ExecutionModeShutdown
+	ExecutionModeMatchEnd
+	ExecutionModeMatchTickOverrun
+	ExecutionModeMatchmakerScorer
+	ExecutionModeMatchCreate
+
+	// executionModeCount is the number of execution modes, and must remain last.
+	executionModeCount
+)
+
+// String returns the value the server sets in RUNTIME_CTX_MODE for the execution mode.
+func (m ExecutionMode) String() string {
+	switch m {
+	case ExecutionModeEvent:
+		return "event"
+	case ExecutionModeRunOnce:
+		return "run_once"
+	case ExecutionModeRpc:
+		return "rpc"
+	case ExecutionModeBefore:
+		return "before"
+	case ExecutionModeAfter:
+		return "after"
+	case ExecutionModeMatch:
+		return "match"
+	case ExecutionModeMatchmaker:
+		return "matchmaker"
+	case ExecutionModeMatchmakerOverride:
+		return "matchmaker_override"
+	case ExecutionModeMatchmakerProcessor:
+		return "matchmaker_processor"
+	case ExecutionModeLeaderboardReset:
+		return "leaderboard_reset"
+	case ExecutionModeTournamentReset:
+		return "tournament_reset"
+	case ExecutionModeTournamentEnd:
+		return "tournament_end"
+	case ExecutionModePurchaseNotificationApple:
+		return "purchase_notification_apple"
+	case ExecutionModeSubscriptionNotificationApple:
+		return "subscription_notification_apple"
+	case ExecutionModePurchaseNotificationGoogle:
+		return "purchase_notification_google"
+	case ExecutionModeSubscriptionNotificationGoogle:
+		return "subscription_notification_google"
+	case ExecutionModeStorageIndexFilter:
+		return "storage_index_filter"
+	case ExecutionModeShutdown:
+		return "shutdown"
+	case ExecutionModeMatchEnd:
+		return "match_end"
+	case ExecutionModeMatchTickOverrun:
+		return "match_tick_overrun"
+	case ExecutionModeMatchmakerScorer:
+		return "matchmaker_scorer"
+	case ExecutionModeMatchCreate:
+		return "match_create"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseExecutionMode returns the execution mode for a RUNTIME_CTX_MODE value, or ExecutionModeUnknown if the value is
+// not recognised.
+func ParseExecutionMode(mode string) ExecutionMode {
+	for m := ExecutionModeEvent; m < executionModeCount; m++ {
+		if m.String() == mode {
+			return m
+		}
+	}
+	return ExecutionModeUnknown
+}
+
+// ContextValue is a value read from a runtime context. Present is false if the context did not hold the value, or
+// held a value of an unexpected type.
+type ContextValue[T any] struct {
+	Value   T
+	Present bool
+}
+
+// Get returns the value and whether it was present.
+func (v ContextValue[T]) Get() (T, bool) {
+	return v.Value, v.Present
+}
+
+// ContextValueOf returns a present context value, for use when building a RuntimeContext.
+func ContextValueOf[T any](value T) ContextValue[T] {
+	return ContextValue[T]{Value: value, Present: true}
+}
+
+/*
+RuntimeContext holds the RUNTIME_CTX_* values of a runtime context with their expected types. Which values are present
+depends on the execution mode: user and session values are only set for functions invoked on behalf of a user,
+headers and query params only for requests made over HTTP, match values only for match handlers, the matchmaker
+entry only for the join callbacks of a presence filling a backfill slot, and the matchmaker region only for matchmaker
+matched functions.
+
+	rc := runtime.FromContext(ctx)
+	if userID, ok := rc.UserID.Get(); ok {
+		...
+	}
+*/
+type RuntimeContext struct {
+	Env              ContextValue[map[string]string]
+	Mode             ContextValue[ExecutionMode]
+	Node             ContextValue[string]
+	Version          ContextValue[string]
+	Headers          ContextValue[map[string][]string]
+	QueryParams      ContextValue[map[string][]string]
+	UserID           ContextValue[string]
+	Username         ContextValue[string]
+	Vars             ContextValue[map[string]string]
+	UserSessionExp   ContextValue[int64]
+	SessionID        ContextValue[string]
+	Lang             ContextValue[string]
+	ClientIP         ContextValue[string]
+	ClientPort       ContextValue[string]
+	MatchID          ContextValue[string]
+	MatchNode        ContextValue[string]
+	MatchLabel       ContextValue[string]
+	MatchTickRate    ContextValue[int]
+	TraceID          ContextValue[string]
+	MatchmakerEntry  ContextValue[MatchmakerEntry]
+	MatchmakerRegion ContextValue[string]
+}
+
+func contextValue[T any](ctx context.Context, key string) ContextValue[T] {
+	value, ok := ctx.Value(key).(T)
+	return ContextValue[T]{Value: value, Present: ok}
+}
+
+// FromContext reads the RUNTIME_CTX_* values of a runtime context. It never panics, reporting values that are
+// missing or of an unexpected type as not present.
+func FromContext(ctx context.Context) *RuntimeContext {
+	rc := &RuntimeContext{
+		Env:              contextValue[map[string]string](ctx, RUNTIME_CTX_ENV),
+		Node:             contextValue[string](ctx, RUNTIME_CTX_NODE),
+		Version:          contextValue[string](ctx, RUNTIME_CTX_VERSION),
+		Headers:          contextValue[map[string][]string](ctx, RUNTIME_CTX_HEADERS),
+		QueryParams:      contextValue[map[string][]string](ctx, RUNTIME_CTX_QUERY_PARAMS),
+		UserID:           contextValue[string](ctx, RUNTIME_CTX_USER_ID),
+		Username:         contextValue[string](ctx, RUNTIME_CTX_USERNAME),
+		Vars:             contextValue[map[string]string](ctx, RUNTIME_CTX_VARS),
+		UserSessionExp:   contextValue[int64](ctx, RUNTIME_CTX_USER_SESSION_EXP),
+		SessionID:        contextValue[string](ctx, RUNTIME_CTX_SESSION_ID),
+		Lang:             contextValue[string](ctx, RUNTIME_CTX_LANG),
+		ClientIP:         contextValue[string](ctx, RUNTIME_CTX_CLIENT_IP),
+		ClientPort:       contextValue[string](ctx, RUNTIME_CTX_CLIENT_PORT),
+		MatchID:          contextValue[string](ctx, RUNTIME_CTX_MATCH_ID),
+		MatchNode:        contextValue[string](ctx, RUNTIME_CTX_MATCH_NODE),
+		MatchLabel:       contextValue[string](ctx, RUNTIME_CTX_MATCH_LABEL),
+		MatchTickRate:    contextValue[int](ctx, RUNTIME_CTX_MATCH_TICK_RATE),
+		TraceID:          contextValue[string](ctx, RUNTIME_CTX_TRACE_ID),
+		MatchmakerEntry:  contextValue[MatchmakerEntry](ctx, RUNTIME_CTX_MATCHMAKER_ENTRY),
+		MatchmakerRegion: contextValue[string](ctx, RUNTIME_CTX_MATCHMAKER_REGION),
+	}
+	if mode, ok := ctx.Value(RUNTIME_CTX_MODE).(string); ok {
+		rc.Mode = ContextValueOf(ParseExecutionMode(mode))
+	}
+	return rc
+}
+
+func withContextValue[T any](ctx context.Context, key string, value ContextValue[T]) context.Context {
+	if !value.Present {
+		return ctx
+	}
+	return context.WithValue(ctx, key, value.Value)
+}
+
+// NewContext returns a copy of parent holding the present values of the runtime context under their RUNTIME_CTX_*
+// keys, with the types the server uses, so that FromContext returns them.
+func NewContext(parent context.Context, rc *RuntimeContext) context.Context {
+	ctx := parent
+	ctx = withContextValue(ctx, RUNTIME_CTX_ENV, rc.Env)
+	if rc.Mode.Present {
+		ctx = context.WithValue(ctx, RUNTIME_CTX_MODE, rc.Mode.Value.String())
+	}
+	ctx = withContextValue(ctx, RUNTIME_CTX_NODE, rc.Node)
+	ctx = withContextValue(ctx, RUNTIME_CTX_VERSION, rc.Version)
+	ctx = withContextValue(ctx, RUNTIME_CTX_HEADERS, rc.Headers)
+	ctx = withContextValue(ctx, RUNTIME_CTX_QUERY_PARAMS, rc.QueryParams)
+	ctx = withContextValue(ctx, RUNTIME_CTX_USER_ID, rc.UserID)
+	ctx = withContextValue(ctx, RUNTIME_CTX_USERNAME, rc.Username)
+	ctx = withContextValue(ctx, RUNTIME_CTX_VARS, rc.Vars)
+	ctx = withContextValue(ctx, RUNTIME_CTX_USER_SESSION_EXP, rc.UserSessionExp)
+	ctx = withContextValue(ctx, RUNTIME_CTX_SESSION_ID, rc.SessionID)
+	ctx = withContextValue(ctx, RUNTIME_CTX_LANG, rc.Lang)
+	ctx = withContextValue(ctx, RUNTIME_CTX_CLIENT_IP, rc.ClientIP)
+	ctx = withContextValue(ctx, RUNTIME_CTX_CLIENT_PORT, rc.ClientPort)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCH_ID, rc.MatchID)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCH_NODE, rc.MatchNode)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCH_LABEL, rc.MatchLabel)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCH_TICK_RATE, rc.MatchTickRate)
+	ctx = withContextValue(ctx, RUNTIME_CTX_TRACE_ID, rc.TraceID)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCHMAKER_ENTRY, rc.MatchmakerEntry)
+	ctx = withContextValue(ctx, RUNTIME_CTX_MATCHMAKER_REGION, rc.MatchmakerRegion)
+	return ctx
+}
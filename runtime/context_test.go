@@ -0,0 +1,74 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRuntimeContext(t *testing.T) {
+	ctx := NewContext(context.Background(), &RuntimeContext{
+		Mode:           ContextValueOf(ExecutionModeRpc),
+		UserID:         ContextValueOf("4c2ae592-b2a7-445e-98ec-697694478b1c"),
+		Vars:           ContextValueOf(map[string]string{"tier": "gold"}),
+		UserSessionExp: ContextValueOf(int64(1700000000)),
+		MatchTickRate:  ContextValueOf(10),
+	})
+	if mode, _ := ctx.Value(RUNTIME_CTX_MODE).(string); mode != "rpc" {
+		t.Fatalf("expected mode to be stored as the server value, got %q", mode)
+	}
+
+	rc := FromContext(ctx)
+	if mode, ok := rc.Mode.Get(); !ok || mode != ExecutionModeRpc {
+		t.Errorf("unexpected mode %v", rc.Mode)
+	}
+	if userID, ok := rc.UserID.Get(); !ok || userID != "4c2ae592-b2a7-445e-98ec-697694478b1c" {
+		t.Errorf("unexpected user ID %v", rc.UserID)
+	}
+	if rc.Vars.Value["tier"] != "gold" || rc.UserSessionExp.Value != 1700000000 || rc.MatchTickRate.Value != 10 {
+		t.Errorf("unexpected values %+v", rc)
+	}
+	if rc.Username.Present || rc.MatchID.Present || rc.Headers.Present {
+		t.Errorf("expected values that were not set to be missing, got %+v", rc)
+	}
+
+	ctx = context.WithValue(ctx, RUNTIME_CTX_USER_SESSION_EXP, "not a number")
+	ctx = context.WithValue(ctx, RUNTIME_CTX_MODE, "custom")
+	rc = FromContext(ctx)
+	if rc.UserSessionExp.Present {
+		t.Errorf("expected value of the wrong type to be missing, got %v", rc.UserSessionExp)
+	}
+	if mode, ok := rc.Mode.Get(); !ok || mode != ExecutionModeUnknown {
+		t.Errorf("expected unrecognised mode to be unknown, got %v", rc.Mode)
+	}
+}
+
+func TestParseExecutionMode(t *testing.T) {
+	for m := ExecutionModeEvent; m < executionModeCount; m++ {
+		if m.String() == "unknown" {
+			t.Errorf("execution mode %d has no name", m)
+		}
+		if parsed := ParseExecutionMode(m.String()); parsed != m {
+			t.Errorf("expected %q to parse as %d, got %d", m, m, parsed)
+		}
+	}
+	if mode := ParseExecutionMode("match_create"); mode != ExecutionModeMatchCreate {
+		t.Errorf("expected match create mode, got %v", mode)
+	}
+	if mode := ParseExecutionMode("unknown"); mode != ExecutionModeUnknown {
+		t.Errorf("expected unknown mode, got %v", mode)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"maps"
+)
+
+// ErrorCode is a gRPC status code, as carried in Error.Code.
+type ErrorCode int
+
+const (
+	ErrorCodeOK ErrorCode = iota
+	ErrorCodeCanceled
+	ErrorCodeUnknown
+	ErrorCodeInvalidArgument
+	ErrorCodeDeadlineExceeded
+	ErrorCodeNotFound
+	ErrorCodeAlreadyExists
+	ErrorCodePermissionDenied
+	ErrorCodeResourceExhausted
+	ErrorCodeFailedPrecondition
+	ErrorCodeAborted
+	ErrorCodeOutOfRange
+	ErrorCodeUnimplemented
+	ErrorCodeInternal
+	ErrorCodeUnavailable
+	ErrorCodeDataLoss
+	ErrorCodeUnauthenticated
+)
+
+// String returns the gRPC name of the code, such as "INVALID_ARGUMENT".
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrorCodeOK:
+		return "OK"
+	case ErrorCodeCanceled:
+		return "CANCELLED"
+	case ErrorCodeUnknown:
+		return "UNKNOWN"
+	case ErrorCodeInvalidArgument:
+		return "INVALID_ARGUMENT"
+	case ErrorCodeDeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case ErrorCodeNotFound:
+		return "NOT_FOUND"
+	case ErrorCodeAlreadyExists:
+		return "ALREADY_EXISTS"
+	case ErrorCodePermissionDenied:
+		return "PERMISSION_DENIED"
+	case ErrorCodeResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case ErrorCodeFailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case ErrorCodeAborted:
+		return "ABORTED"
+	case ErrorCodeOutOfRange:
+		return "OUT_OF_RANGE"
+	case ErrorCodeUnimplemented:
+		return "UNIMPLEMENTED"
+	case ErrorCodeInternal:
+		return "INTERNAL"
+	case ErrorCodeUnavailable:
+		return "UNAVAILABLE"
+	case ErrorCodeDataLoss:
+		return "DATA_LOSS"
+	case ErrorCodeUnauthenticated:
+		return "UNAUTHENTICATED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+/*
+NewErrorWithReason returns a new error with a typed code and a machine-readable reason, which clients can match on
+without parsing the message.
+
+	return runtime.NewErrorWithReason(runtime.ErrorCodeFailedPrecondition, "INSUFFICIENT_COINS", "not enough coins").
+		WithDetails(map[string]string{"required": "100"})
+*/
+func NewErrorWithReason(code ErrorCode, reason, message string) *Error {
+	return &Error{Message: message, Code: int(code), Reason: reason}
+}
+
+// ErrorCode returns the code of the error as an ErrorCode.
+func (e *Error) ErrorCode() ErrorCode {
+	return ErrorCode(e.Code)
+}
+
+// WithDetails returns a copy of the error with the details added to any it already has.
+func (e *Error) WithDetails(details map[string]string) *Error {
+	err := *e
+	err.Details = make(map[string]string, len(e.Details)+len(details))
+	maps.Copy(err.Details, e.Details)
+	maps.Copy(err.Details, details)
+	return &err
+}
+
+// WithCause returns a copy of the error wrapping the cause, so that errors.Is and errors.As match it.
+func (e *Error) WithCause(cause error) *Error {
+	err := *e
+	err.Cause = cause
+	return &err
+}
+
+// Unwrap returns the underlying cause of the error, if any.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+/*
+Is reports whether the error matches target, an *Error that sets a reason, by code and reason. Messages and details
+are not compared. Errors without a reason, such as those created by NewError, only match themselves, so distinct
+errors that share a code stay distinct. Use ErrorCodeOf to match by code alone.
+
+	if errors.Is(err, &runtime.Error{Code: int(runtime.ErrorCodeFailedPrecondition), Reason: "INSUFFICIENT_COINS"}) {
+		...
+	}
+	if runtime.ErrorCodeOf(err) == runtime.ErrorCodeNotFound {
+		...
+	}
+*/
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Reason == "" {
+		return false
+	}
+	return e.Code == t.Code && e.Reason == t.Reason
+}
+
+type sentinelError struct {
+	err    error
+	code   ErrorCode
+	reason string
+}
+
+// sentinelErrors holds the default code and reason of each sentinel error, used by ToError and ErrorCodeOf.
+var sentinelErrors = []sentinelError{
+	{ErrStorageRejectedVersion, ErrorCodeFailedPrecondition, "STORAGE_REJECTED_VERSION"},
+	{ErrStorageRejectedPermission, ErrorCodePermissionDenied, "STORAGE_REJECTED_PERMISSION"},
+
+	{ErrChannelIDInvalid, ErrorCodeInvalidArgument, "CHANNEL_ID_INVALID"},
+	{ErrChannelCursorInvalid, ErrorCodeInvalidArgument, "CHANNEL_CURSOR_INVALID"},
+	{ErrChannelGroupNotFound, ErrorCodeNotFound, "CHANNEL_GROUP_NOT_FOUND"},
+
+	{ErrInvalidChannelTarget, ErrorCodeInvalidArgument, "INVALID_CHANNEL_TARGET"},
+	{ErrInvalidChannelType, ErrorCodeInvalidArgument, "INVALID_CHANNEL_TYPE"},
+
+	{ErrFriendInvalidCursor, ErrorCodeInvalidArgument, "FRIEND_INVALID_CURSOR"},
+
+	{ErrLeaderboardNotFound, ErrorCodeNotFound, "LEADERBOARD_NOT_FOUND"},
+
+	{ErrTournamentNotFound, ErrorCodeNotFound, "TOURNAMENT_NOT_FOUND"},
+	{ErrTournamentAuthoritative, ErrorCodePermissionDenied, "TOURNAMENT_AUTHORITATIVE"},
+	{ErrTournamentMaxSizeReached, ErrorCodeResourceExhausted, "TOURNAMENT_MAX_SIZE_REACHED"},
+	{ErrTournamentOutsideDuration, ErrorCodeFailedPrecondition, "TOURNAMENT_OUTSIDE_DURATION"},
+	{ErrTournamentWriteMaxNumScoreReached, ErrorCodeResourceExhausted, "TOURNAMENT_WRITE_MAX_NUM_SCORE_REACHED"},
+	{ErrTournamentWriteJoinRequired, ErrorCodeFailedPrecondition, "TOURNAMENT_WRITE_JOIN_REQUIRED"},
+
+	{ErrMatchmakerQueryInvalid, ErrorCodeInvalidArgument, "MATCHMAKER_QUERY_INVALID"},
+	{ErrMatchmakerDuplicateSession, ErrorCodeAlreadyExists, "MATCHMAKER_DUPLICATE_SESSION"},
+	{ErrMatchmakerIndex, ErrorCodeInternal, "MATCHMAKER_INDEX"},
+	{ErrMatchmakerDelete, ErrorCodeInternal, "MATCHMAKER_DELETE"},
+	{ErrMatchmakerNotAvailable, ErrorCodeUnavailable, "MATCHMAKER_NOT_AVAILABLE"},
+	{ErrMatchmakerTooManyTickets, ErrorCodeResourceExhausted, "MATCHMAKER_TOO_MANY_TICKETS"},
+	{ErrMatchmakerTicketNotFound, ErrorCodeNotFound, "MATCHMAKER_TICKET_NOT_FOUND"},
+	{ErrMatchmakerStagesInvalid, ErrorCodeInvalidArgument, "MATCHMAKER_STAGES_INVALID"},
+
+	{ErrPartyClosed, ErrorCodeFailedPrecondition, "PARTY_CLOSED"},
+	{ErrPartyFull, ErrorCodeResourceExhausted, "PARTY_FULL"},
+	{ErrPartyJoinRequestDuplicate, ErrorCodeAlreadyExists, "PARTY_JOIN_REQUEST_DUPLICATE"},
+	{ErrPartyJoinRequestAlreadyMember, ErrorCodeAlreadyExists, "PARTY_JOIN_REQUEST_ALREADY_MEMBER"},
+	{ErrPartyJoinRequestsFull, ErrorCodeResourceExhausted, "PARTY_JOIN_REQUESTS_FULL"},
+	{ErrPartyNotLeader, ErrorCodePermissionDenied, "PARTY_NOT_LEADER"},
+	{ErrPartyNotMember, ErrorCodeNotFound, "PARTY_NOT_MEMBER"},
+	{ErrPartyNotRequest, ErrorCodeNotFound, "PARTY_NOT_REQUEST"},
+	{ErrPartyAcceptRequest, ErrorCodeFailedPrecondition, "PARTY_ACCEPT_REQUEST"},
+	{ErrPartyRemove, ErrorCodeFailedPrecondition, "PARTY_REMOVE"},
+	{ErrPartyRemoveSelf, ErrorCodeInvalidArgument, "PARTY_REMOVE_SELF"},
+	{ErrPartyLabelTooLong, ErrorCodeInvalidArgument, "PARTY_LABEL_TOO_LONG"},
+
+	{ErrGracePeriodExpired, ErrorCodeFailedPrecondition, "GRACE_PERIOD_EXPIRED"},
+
+	{ErrGroupNameInUse, ErrorCodeAlreadyExists, "GROUP_NAME_IN_USE"},
+	{ErrGroupPermissionDenied, ErrorCodePermissionDenied, "GROUP_PERMISSION_DENIED"},
+	{ErrGroupNoUpdateOps, ErrorCodeInvalidArgument, "GROUP_NO_UPDATE_OPS"},
+	{ErrGroupNotUpdated, ErrorCodeFailedPrecondition, "GROUP_NOT_UPDATED"},
+	{ErrGroupNotFound, ErrorCodeNotFound, "GROUP_NOT_FOUND"},
+	{ErrGroupFull, ErrorCodeResourceExhausted, "GROUP_FULL"},
+	{ErrGroupUserNotFound, ErrorCodeNotFound, "GROUP_USER_NOT_FOUND"},
+	{ErrGroupLastSuperadmin, ErrorCodeFailedPrecondition, "GROUP_LAST_SUPERADMIN"},
+	{ErrGroupUserInvalidCursor, ErrorCodeInvalidArgument, "GROUP_USER_INVALID_CURSOR"},
+	{ErrUserGroupInvalidCursor, ErrorCodeInvalidArgument, "USER_GROUP_INVALID_CURSOR"},
+	{ErrGroupCreatorInvalid, ErrorCodeInvalidArgument, "GROUP_CREATOR_INVALID"},
+
+	{ErrWalletLedgerInvalidCursor, ErrorCodeInvalidArgument, "WALLET_LEDGER_INVALID_CURSOR"},
+
+	{ErrCannotEncodeParams, ErrorCodeInvalidArgument, "CANNOT_ENCODE_PARAMS"},
+	{ErrCannotDecodeParams, ErrorCodeInvalidArgument, "CANNOT_DECODE_PARAMS"},
+	{ErrMatchIdInvalid, ErrorCodeInvalidArgument, "MATCH_ID_INVALID"},
+	{ErrMatchNotFound, ErrorCodeNotFound, "MATCH_NOT_FOUND"},
+	{ErrMatchBusy, ErrorCodeUnavailable, "MATCH_BUSY"},
+	{ErrMatchStateFailed, ErrorCodeInternal, "MATCH_STATE_FAILED"},
+	{ErrMatchLabelTooLong, ErrorCodeInvalidArgument, "MATCH_LABEL_TOO_LONG"},
+	{ErrMatchTickRateInvalid, ErrorCodeInvalidArgument, "MATCH_TICK_RATE_INVALID"},
+	{ErrDeferredBroadcastFull, ErrorCodeResourceExhausted, "DEFERRED_BROADCAST_FULL"},
+	{ErrMatchSnapshotUnsupported, ErrorCodeUnimplemented, "MATCH_SNAPSHOT_UNSUPPORTED"},
+	{ErrMatchTimerUnsupported, ErrorCodeUnimplemented, "MATCH_TIMER_UNSUPPORTED"},
+	{ErrMatchTimerInvalid, ErrorCodeInvalidArgument, "MATCH_TIMER_INVALID"},
+	{ErrMatchReconnectUnsupported, ErrorCodeUnimplemented, "MATCH_RECONNECT_UNSUPPORTED"},
+	{ErrMatchEnded, ErrorCodeFailedPrecondition, "MATCH_ENDED"},
+	{ErrMatchResultInvalid, ErrorCodeInvalidArgument, "MATCH_RESULT_INVALID"},
+	{ErrMatchBackfillInvalid, ErrorCodeInvalidArgument, "MATCH_BACKFILL_INVALID"},
+
+	{ErrRatingRulesetNotFound, ErrorCodeNotFound, "RATING_RULESET_NOT_FOUND"},
+	{ErrRatingUpdateInvalid, ErrorCodeInvalidArgument, "RATING_UPDATE_INVALID"},
+
+	{ErrQueryNumberInvalid, ErrorCodeInvalidArgument, "QUERY_NUMBER_INVALID"},
+
+	{ErrSatoriConfigurationInvalid, ErrorCodeFailedPrecondition, "SATORI_CONFIGURATION_INVALID"},
+
+	{context.Canceled, ErrorCodeCanceled, ""},
+	{context.DeadlineExceeded, ErrorCodeDeadlineExceeded, ""},
+}
+
+/*
+ToError returns err as an *Error. If err is or wraps an *Error, that error is returned. Otherwise a new error is
+returned with the message of err and the default code and reason of the sentinel error it wraps, or
+ErrorCodeInternal if it wraps none, with err as its cause so that errors.Is still matches the sentinel. ToError returns
+nil if err is nil.
+
+	err := runtime.ToError(runtime.ErrGroupFull) // Code 8 (RESOURCE_EXHAUSTED), Reason "GROUP_FULL"
+*/
+func ToError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var runtimeErr *Error
+	if errors.As(err, &runtimeErr) {
+		return runtimeErr
+	}
+	for _, sentinel := range sentinelErrors {
+		if errors.Is(err, sentinel.err) {
+			return &Error{Message: err.Error(), Code: int(sentinel.code), Reason: sentinel.reason, Cause: err}
+		}
+	}
+	return &Error{Message: err.Error(), Code: int(ErrorCodeInternal), Cause: err}
+}
+
+// ErrorCodeOf returns the code ToError would give err, or ErrorCodeOK if err is nil.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrorCodeOK
+	}
+	return ToError(err).ErrorCode()
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	base := NewErrorWithReason(ErrorCodeFailedPrecondition, "INSUFFICIENT_COINS", "not enough coins")
+	err := base.WithDetails(map[string]string{"required": "100"}).WithCause(ErrGroupFull)
+	if base.Details != nil || base.Cause != nil {
+		t.Fatalf("expected base error to be unchanged, got %+v", base)
+	}
+	if err.Code != 9 || err.ErrorCode() != ErrorCodeFailedPrecondition || err.ErrorCode().String() != "FAILED_PRECONDITION" {
+		t.Errorf("unexpected code %v", err.Code)
+	}
+	if name := ErrorCodeCanceled.String(); name != "CANCELLED" {
+		t.Errorf("expected the gRPC name of the cancelled code, got %v", name)
+	}
+	if err.Details["required"] != "100" {
+		t.Errorf("unexpected details %v", err.Details)
+	}
+
+	wrapped := fmt.Errorf("claim failed: %w", err)
+	if !errors.Is(wrapped, ErrGroupFull) {
+		t.Error("expected error to match its cause")
+	}
+	if ErrorCodeOf(wrapped) != ErrorCodeFailedPrecondition {
+		t.Error("expected error code to be found through the wrapping")
+	}
+	if errors.Is(wrapped, &Error{Code: int(ErrorCodeFailedPrecondition)}) {
+		t.Error("expected error not to match a target without a reason by code")
+	}
+	if !errors.Is(wrapped, &Error{Code: int(ErrorCodeFailedPrecondition), Reason: "INSUFFICIENT_COINS"}) {
+		t.Error("expected error to match by code and reason")
+	}
+	if errors.Is(wrapped, &Error{Code: int(ErrorCodeFailedPrecondition), Reason: "GROUP_FULL"}) {
+		t.Error("expected error not to match another reason")
+	}
+	var runtimeErr *Error
+	if !errors.As(wrapped, &runtimeErr) || runtimeErr.Reason != "INSUFFICIENT_COINS" {
+		t.Errorf("unexpected errors.As result %v", runtimeErr)
+	}
+
+	errA, errB := NewError("a", 3), NewError("b", 3)
+	if errors.Is(errA, errB) || errors.Is(fmt.Errorf("wrapped: %w", errA), errB) {
+		t.Error("expected distinct errors with the same code not to match")
+	}
+	if !errors.Is(fmt.Errorf("wrapped: %w", errA), errA) {
+		t.Error("expected error to match itself")
+	}
+}
+
+func TestToError(t *testing.T) {
+	cases := []struct {
+		err    error
+		code   ErrorCode
+		reason string
+	}{
+		{ErrGroupFull, ErrorCodeResourceExhausted, "GROUP_FULL"},
+		{fmt.Errorf("%w: unexpected token", ErrMatchmakerQueryInvalid), ErrorCodeInvalidArgument, "MATCHMAKER_QUERY_INVALID"},
+		{ErrMatchmakerTooManyTickets, ErrorCodeResourceExhausted, "MATCHMAKER_TOO_MANY_TICKETS"},
+		{ErrLeaderboardNotFound, ErrorCodeNotFound, "LEADERBOARD_NOT_FOUND"},
+		{context.DeadlineExceeded, ErrorCodeDeadlineExceeded, ""},
+		{errors.New("boom"), ErrorCodeInternal, ""},
+		{NewError("custom", 14), ErrorCodeUnavailable, ""},
+	}
+	for _, c := range cases {
+		err := ToError(c.err)
+		if err.ErrorCode() != c.code || err.Reason != c.reason || err.Message != c.err.Error() {
+			t.Errorf("%v: unexpected error %+v", c.err, err)
+		}
+		if !errors.Is(err, c.err) {
+			t.Errorf("%v: expected converted error to match the original", c.err)
+		}
+		if ErrorCodeOf(c.err) != c.code {
+			t.Errorf("%v: unexpected code %v", c.err, ErrorCodeOf(c.err))
+		}
+	}
+
+	if ToError(nil) != nil || ErrorCodeOf(nil) != ErrorCodeOK {
+		t.Error("expected nil error to convert to nil")
+	}
+	for _, sentinel := range sentinelErrors {
+		if ToError(sentinel.err).ErrorCode() != sentinel.code {
+			t.Errorf("%v: sentinel shadowed by an earlier entry", sentinel.err)
+		}
+	}
+}
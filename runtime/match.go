@@ -0,0 +1,233 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+)
+
+// MatchSettings holds the settings a match returns from MatchInit.
+type MatchSettings struct {
+	// The number of times per second the match loop runs, between 1 and 60.
+	TickRate int
+	// The match label, at most 2048 bytes.
+	Label string
+}
+
+/*
+TypedMatch is an authoritative match handler whose callbacks receive and return a state of type S, rather than the
+untyped state of Match. NewTypedMatch adapts it into a Match that can be registered with the server.
+
+As with Match, returning a nil state from a callback ends the match, so S should be a pointer or other type that can
+be nil if the match needs to end itself. Returning a nil state from MatchInit fails to create the match.
+*/
+type TypedMatch[S any] interface {
+	MatchInit(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, params map[string]interface{}) (S, MatchSettings)
+	MatchJoinAttempt(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, presence Presence, metadata map[string]string) (S, bool, string)
+	MatchJoin(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, presences []Presence) S
+	MatchLeave(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, presences []Presence) S
+	MatchLoop(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, messages []MatchData) S
+	MatchTerminate(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, graceSeconds int) S
+	MatchSignal(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, data string) (S, string)
+}
+
+// TypedMatchSnapshotter is the typed form of MatchSnapshotter. The Match returned by NewTypedMatch supports
+// MatchFeatureSnapshot if the typed match implements TypedMatchSnapshotter.
+type TypedMatchSnapshotter[S any] interface {
+	MatchSnapshot(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, state S) ([]byte, error)
+	MatchRestore(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, data []byte) (S, error)
+}
+
+// TypedMatchTimer is the typed form of MatchTimer. The Match returned by NewTypedMatch supports MatchFeatureTimer if
+// the typed match implements TypedMatchTimer.
+type TypedMatchTimer[S any] interface {
+	MatchTimer(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, key string, data []byte) S
+}
+
+// TypedMatchReconnector is the typed form of MatchReconnector. The Match returned by NewTypedMatch supports
+// MatchFeatureReconnect if the typed match implements TypedMatchReconnector.
+type TypedMatchReconnector[S any] interface {
+	MatchRejoin(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, presence Presence) S
+	MatchReservationExpired(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state S, presence Presence) S
+}
+
+// MatchFeature is an optional feature of a Match, which it provides by implementing the interface of the feature.
+type MatchFeature int
+
+const (
+	// MatchFeatureSnapshot is provided through MatchSnapshotter.
+	MatchFeatureSnapshot MatchFeature = iota
+	// MatchFeatureTimer is provided through MatchTimer.
+	MatchFeatureTimer
+	// MatchFeatureReconnect is provided through MatchReconnector.
+	MatchFeatureReconnect
+)
+
+// MatchFeatures is optionally implemented by a Match that implements the interfaces of features it may not support,
+// such as the Match returned by NewTypedMatch, to report which of them it supports.
+type MatchFeatures interface {
+	SupportsMatchFeature(feature MatchFeature) bool
+}
+
+// MatchSupports returns true if the match implements the interface of the feature and, if it implements
+// MatchFeatures, reports the feature as supported. The server only uses the features a match supports.
+func MatchSupports(match Match, feature MatchFeature) bool {
+	var ok bool
+	switch feature {
+	case MatchFeatureSnapshot:
+		_, ok = match.(MatchSnapshotter)
+	case MatchFeatureTimer:
+		_, ok = match.(MatchTimer)
+	case MatchFeatureReconnect:
+		_, ok = match.(MatchReconnector)
+	}
+	if features, implemented := match.(MatchFeatures); ok && implemented {
+		return features.SupportsMatchFeature(feature)
+	}
+	return ok
+}
+
+// NewTypedMatch returns a Match that passes its state to the typed match. The Match implements MatchSnapshotter,
+// MatchTimer and MatchReconnector, and reports through MatchFeatures which of them the typed match supports.
+func NewTypedMatch[S any](match TypedMatch[S]) Match {
+	m := &typedMatch[S]{match: match}
+	m.snapshotter, _ = match.(TypedMatchSnapshotter[S])
+	m.timer, _ = match.(TypedMatchTimer[S])
+	m.reconnector, _ = match.(TypedMatchReconnector[S])
+	return m
+}
+
+// RegisterMatchTyped registers a typed match handler with the given name through Initializer.RegisterMatch.
+func RegisterMatchTyped[S any](initializer Initializer, name string, fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule) (TypedMatch[S], error)) error {
+	return initializer.RegisterMatch(name, func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule) (Match, error) {
+		match, err := fn(ctx, logger, db, nk)
+		if err != nil {
+			return nil, err
+		}
+		return NewTypedMatch(match), nil
+	})
+}
+
+type typedMatch[S any] struct {
+	match       TypedMatch[S]
+	snapshotter TypedMatchSnapshotter[S]
+	timer       TypedMatchTimer[S]
+	reconnector TypedMatchReconnector[S]
+}
+
+// untyped returns the state as an interface value, which is nil if the state is a nil pointer, map, slice, channel,
+// function or interface.
+func (m *typedMatch[S]) untyped(state S) interface{} {
+	v := reflect.ValueOf(&state).Elem()
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return state
+}
+
+// typed returns the state passed to a callback, which the adapter always stores as an S.
+func (m *typedMatch[S]) typed(state interface{}) S {
+	s, _ := state.(S)
+	return s
+}
+
+func (m *typedMatch[S]) MatchInit(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, params map[string]interface{}) (interface{}, int, string) {
+	state, settings := m.match.MatchInit(ctx, logger, db, nk, params)
+	return m.untyped(state), settings.TickRate, settings.Label
+}
+
+func (m *typedMatch[S]) MatchJoinAttempt(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presence Presence, metadata map[string]string) (interface{}, bool, string) {
+	s, accepted, reason := m.match.MatchJoinAttempt(ctx, logger, db, nk, dispatcher, tick, m.typed(state), presence, metadata)
+	return m.untyped(s), accepted, reason
+}
+
+func (m *typedMatch[S]) MatchJoin(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presences []Presence) interface{} {
+	return m.untyped(m.match.MatchJoin(ctx, logger, db, nk, dispatcher, tick, m.typed(state), presences))
+}
+
+func (m *typedMatch[S]) MatchLeave(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presences []Presence) interface{} {
+	return m.untyped(m.match.MatchLeave(ctx, logger, db, nk, dispatcher, tick, m.typed(state), presences))
+}
+
+func (m *typedMatch[S]) MatchLoop(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, messages []MatchData) interface{} {
+	return m.untyped(m.match.MatchLoop(ctx, logger, db, nk, dispatcher, tick, m.typed(state), messages))
+}
+
+func (m *typedMatch[S]) MatchTerminate(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, graceSeconds int) interface{} {
+	return m.untyped(m.match.MatchTerminate(ctx, logger, db, nk, dispatcher, tick, m.typed(state), graceSeconds))
+}
+
+func (m *typedMatch[S]) MatchSignal(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, data string) (interface{}, string) {
+	s, result := m.match.MatchSignal(ctx, logger, db, nk, dispatcher, tick, m.typed(state), data)
+	return m.untyped(s), result
+}
+
+// SupportsMatchFeature reports the typed interfaces the typed match implements. The callbacks of the features it does
+// not support are not called by the server, and keep the state unchanged if they are.
+func (m *typedMatch[S]) SupportsMatchFeature(feature MatchFeature) bool {
+	switch feature {
+	case MatchFeatureSnapshot:
+		return m.snapshotter != nil
+	case MatchFeatureTimer:
+		return m.timer != nil
+	case MatchFeatureReconnect:
+		return m.reconnector != nil
+	}
+	return false
+}
+
+func (m *typedMatch[S]) MatchSnapshot(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, state interface{}) ([]byte, error) {
+	if m.snapshotter == nil {
+		return nil, ErrMatchSnapshotUnsupported
+	}
+	return m.snapshotter.MatchSnapshot(ctx, logger, db, nk, tick, m.typed(state))
+}
+
+func (m *typedMatch[S]) MatchRestore(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, data []byte) (interface{}, error) {
+	if m.snapshotter == nil {
+		return nil, ErrMatchSnapshotUnsupported
+	}
+	state, err := m.snapshotter.MatchRestore(ctx, logger, db, nk, tick, data)
+	if err != nil {
+		return nil, err
+	}
+	return m.untyped(state), nil
+}
+
+func (m *typedMatch[S]) MatchTimer(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, key string, data []byte) interface{} {
+	if m.timer == nil {
+		return state
+	}
+	return m.untyped(m.timer.MatchTimer(ctx, logger, db, nk, dispatcher, tick, m.typed(state), key, data))
+}
+
+func (m *typedMatch[S]) MatchRejoin(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presence Presence) interface{} {
+	if m.reconnector == nil {
+		return state
+	}
+	return m.untyped(m.reconnector.MatchRejoin(ctx, logger, db, nk, dispatcher, tick, m.typed(state), presence))
+}
+
+func (m *typedMatch[S]) MatchReservationExpired(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presence Presence) interface{} {
+	if m.reconnector == nil {
+		return state
+	}
+	return m.untyped(m.reconnector.MatchReservationExpired(ctx, logger, db, nk, dispatcher, tick, m.typed(state), presence))
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-common/runtime/runtimetest"
+)
+
+type counterState struct {
+	players int
+	ticks   int
+}
+
+// counterMatch counts players and ticks, and ends once the last player leaves.
+type counterMatch struct{}
+
+func (m *counterMatch) MatchInit(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, params map[string]interface{}) (*counterState, runtime.MatchSettings) {
+	return &counterState{}, runtime.MatchSettings{TickRate: 5, Label: "counter"}
+}
+
+func (m *counterMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, presence runtime.Presence, metadata map[string]string) (*counterState, bool, string) {
+	return state, state.players < 1, "full"
+}
+
+func (m *counterMatch) MatchJoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, presences []runtime.Presence) *counterState {
+	state.players += len(presences)
+	return state
+}
+
+func (m *counterMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, presences []runtime.Presence) *counterState {
+	state.players -= len(presences)
+	return state
+}
+
+func (m *counterMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, messages []runtime.MatchData) *counterState {
+	if state.players == 0 && state.ticks > 0 {
+		return nil
+	}
+	state.ticks++
+	return state
+}
+
+func (m *counterMatch) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, graceSeconds int) *counterState {
+	return state
+}
+
+func (m *counterMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, data string) (*counterState, string) {
+	return state, data
+}
+
+func TestTypedMatch(t *testing.T) {
+	ctx := context.Background()
+	initializer := runtimetest.NewInitializer(runtimetest.NewNakamaModule())
+	var mode runtime.ExecutionMode
+	if err := runtime.RegisterMatchTyped(initializer, "counter", func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.TypedMatch[*counterState], error) {
+		mode, _ = runtime.FromContext(ctx).Mode.Get()
+		return &counterMatch{}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	match, err := initializer.Match(ctx, "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != runtime.ExecutionModeMatchCreate {
+		t.Fatalf("expected the match to be created in match create mode, got %v", mode)
+	}
+
+	harness := runtimetest.NewMatchHarness(match, initializer.NK)
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if harness.TickRate() != 5 || harness.Label() != "counter" {
+		t.Fatalf("unexpected match settings %v %q", harness.TickRate(), harness.Label())
+	}
+	alice := runtimetest.NewPresence("4c2ae592-b2a7-445e-98ec-697694478b1c", "alice")
+	if accepted, _, _ := harness.Join(ctx, alice, nil); !accepted {
+		t.Fatal("expected alice to join")
+	}
+	if accepted, reason, _ := harness.Join(ctx, runtimetest.NewPresence("8a3f6d4e-0f5c-4b1d-9a51-2c7e8d9b0a13", "bob"), nil); accepted || reason != "full" {
+		t.Fatalf("expected bob to be rejected, got %v %q", accepted, reason)
+	}
+	if err := harness.Run(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if state := harness.State().(*counterState); state.ticks != 3 || state.players != 1 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+
+	if err := harness.Leave(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Tick(ctx); err != nil || !harness.Stopped() {
+		t.Fatalf("expected a nil typed state to end the match, stopped %v, %v", harness.Stopped(), err)
+	}
+}
+
+// snapshotCounterMatch is a counterMatch that can be snapshotted and restored.
+type snapshotCounterMatch struct {
+	counterMatch
+}
+
+func (m *snapshotCounterMatch) MatchSnapshot(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tick int64, state *counterState) ([]byte, error) {
+	return fmt.Appendf(nil, "%d %d", state.players, state.ticks), nil
+}
+
+func (m *snapshotCounterMatch) MatchRestore(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tick int64, data []byte) (*counterState, error) {
+	state := &counterState{}
+	if _, err := fmt.Sscanf(string(data), "%d %d", &state.players, &state.ticks); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func TestTypedMatchSnapshot(t *testing.T) {
+	if runtime.MatchSupports(runtime.NewTypedMatch[*counterState](&counterMatch{}), runtime.MatchFeatureSnapshot) {
+		t.Fatal("expected a typed match without snapshots not to support them")
+	}
+	if !runtime.MatchSupports(runtime.NewTypedMatch[*counterState](&snapshotCounterMatch{}), runtime.MatchFeatureSnapshot) {
+		t.Fatal("expected a typed match with snapshots to support them")
+	}
+
+	ctx := context.Background()
+	harness := runtimetest.NewMatchHarness(runtime.NewTypedMatch[*counterState](&snapshotCounterMatch{}), runtimetest.NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if accepted, _, _ := harness.Join(ctx, runtimetest.NewPresence("4c2ae592-b2a7-445e-98ec-697694478b1c", "alice"), nil); !accepted {
+		t.Fatal("expected alice to join")
+	}
+	if err := harness.Run(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := harness.Migrate(ctx, runtime.NewTypedMatch[*counterState](&snapshotCounterMatch{}), "nakama2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := migrated.State().(*counterState); state.ticks != 2 || state.players != 1 {
+		t.Fatalf("unexpected restored state %+v", state)
+	}
+}
+
+// timerCounterMatch is a counterMatch that adds the timer data to its tick count.
+type timerCounterMatch struct {
+	counterMatch
+}
+
+func (m *timerCounterMatch) MatchTimer(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, key string, data []byte) *counterState {
+	state.ticks += len(data)
+	return state
+}
+
+func TestTypedMatchTimer(t *testing.T) {
+	ctx := context.Background()
+	plain := runtimetest.NewMatchHarness(runtime.NewTypedMatch[*counterState](&counterMatch{}), runtimetest.NewNakamaModule())
+	if err := plain.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Dispatcher().ScheduleAfter(1, "bonus", nil); !errors.Is(err, runtime.ErrMatchTimerUnsupported) {
+		t.Fatalf("expected a typed match without timers not to support them, got %v", err)
+	}
+
+	harness := runtimetest.NewMatchHarness(runtime.NewTypedMatch[*counterState](&timerCounterMatch{}), runtimetest.NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if accepted, _, _ := harness.Join(ctx, runtimetest.NewPresence("4c2ae592-b2a7-445e-98ec-697694478b1c", "alice"), nil); !accepted {
+		t.Fatal("expected alice to join")
+	}
+	if err := harness.Dispatcher().ScheduleAfter(1, "bonus", []byte("0123456789")); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Run(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if state := harness.State().(*counterState); state.ticks != 12 {
+		t.Fatalf("expected the timer to fire, got %+v", state)
+	}
+}
+
+// reconnectCounterMatch is a counterMatch that counts rejoins in its tick count.
+type reconnectCounterMatch struct {
+	counterMatch
+}
+
+func (m *reconnectCounterMatch) MatchRejoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, presence runtime.Presence) *counterState {
+	state.players++
+	state.ticks += 100
+	return state
+}
+
+func (m *reconnectCounterMatch) MatchReservationExpired(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state *counterState, presence runtime.Presence) *counterState {
+	return state
+}
+
+func TestTypedMatchReconnect(t *testing.T) {
+	ctx := context.Background()
+	alice := runtimetest.NewPresence("4c2ae592-b2a7-445e-98ec-697694478b1c", "alice")
+	plain := runtimetest.NewMatchHarness(runtime.NewTypedMatch[*counterState](&counterMatch{}), runtimetest.NewNakamaModule())
+	if err := plain.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Dispatcher().SetReconnectWindow(time.Minute); !errors.Is(err, runtime.ErrMatchReconnectUnsupported) {
+		t.Fatalf("expected a typed match without reconnection not to support it, got %v", err)
+	}
+
+	harness := runtimetest.NewMatchHarness(runtime.NewTypedMatch[*counterState](&reconnectCounterMatch{}), runtimetest.NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Dispatcher().SetReconnectWindow(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if accepted, _, _ := harness.Join(ctx, alice, nil); !accepted {
+		t.Fatal("expected alice to join")
+	}
+	if err := harness.Disconnect(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+	if accepted, _, _ := harness.Join(ctx, alice, nil); !accepted {
+		t.Fatal("expected alice to rejoin")
+	}
+	if state := harness.State().(*counterState); state.players != 1 || state.ticks != 100 {
+		t.Fatalf("expected MatchRejoin to be called, got %+v", state)
+	}
+}
@@ -0,0 +1,548 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchmakerQueryOccur is the requirement a matchmaker query clause places on a match.
+type MatchmakerQueryOccur int
+
+const (
+	// The clause is optional, but at least one optional clause must match if the query has no required clauses.
+	MatchmakerQueryShould MatchmakerQueryOccur = iota
+	// The clause must match, written with a "+" prefix.
+	MatchmakerQueryMust
+	// The clause must not match, written with a "-" prefix.
+	MatchmakerQueryMustNot
+)
+
+func (o MatchmakerQueryOccur) String() string {
+	switch o {
+	case MatchmakerQueryShould:
+		return "SHOULD"
+	case MatchmakerQueryMust:
+		return "MUST"
+	case MatchmakerQueryMustNot:
+		return "MUST_NOT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// matchmakerQueryPropertiesPrefix is the prefix of query fields that refer to matchmaker entry properties.
+const matchmakerQueryPropertiesPrefix = "properties."
+
+type matchmakerQueryClause struct {
+	text  string
+	occur MatchmakerQueryOccur
+	field string
+	boost float64
+	match func(value interface{}) bool
+}
+
+/*
+MatchmakerQuery is a parsed matchmaker query, as given to MatchmakerAdd. It evaluates the query against the properties
+of a matchmaker entry the same way the server does when it searches for tickets to match together.
+
+The query syntax is a sequence of clauses separated by whitespace. Each clause is a term optionally prefixed with a
+field name, and prefixed with "+" if it is required or "-" if it must not match:
+
+	+properties.region:europe -properties.mode:casual properties.skill:>=10 properties.skill:<=20^2
+
+Properties are referred to with a "properties." prefix. Values can be exact terms, quoted phrases, wildcards using
+"*" and "?", regular expressions between slashes, fuzzy terms with a "~" suffix and an optional edit distance, or
+numeric ranges using ">", ">=", "<" and "<=". A "^" suffix boosts the score of a clause. A query of "*" matches any
+entry.
+*/
+type MatchmakerQuery struct {
+	query    string
+	matchAll bool
+	clauses  []*matchmakerQueryClause
+}
+
+// MatchmakerQueryClauseResult describes whether a single query clause matched.
+type MatchmakerQueryClauseResult struct {
+	Clause  string
+	Occur   MatchmakerQueryOccur
+	Boost   float64
+	Matched bool
+}
+
+// MatchmakerQueryResult is the outcome of evaluating a matchmaker query. The score is the sum of the boosts of the
+// required and optional clauses that matched, and is only comparable between results of the same query.
+type MatchmakerQueryResult struct {
+	Matched bool
+	Score   float64
+	Clauses []*MatchmakerQueryClauseResult
+}
+
+// String explains the result, listing each clause and whether it matched.
+func (r *MatchmakerQueryResult) String() string {
+	var b strings.Builder
+	if r.Matched {
+		fmt.Fprintf(&b, "matched with score %v", r.Score)
+	} else {
+		b.WriteString("not matched")
+	}
+	for _, clause := range r.Clauses {
+		status := "matched"
+		if !clause.Matched {
+			status = "not matched"
+		}
+		fmt.Fprintf(&b, "\n  %v %v: %v", clause.Occur, clause.Clause, status)
+	}
+	return b.String()
+}
+
+// ParseMatchmakerQuery parses a matchmaker query. Errors wrap ErrMatchmakerQueryInvalid.
+func ParseMatchmakerQuery(query string) (*MatchmakerQuery, error) {
+	q := &MatchmakerQuery{query: query}
+	if strings.TrimSpace(query) == "*" {
+		q.matchAll = true
+		return q, nil
+	}
+
+	p := &matchmakerQueryParser{input: query}
+	for {
+		p.skipSpace()
+		if p.done() {
+			return q, nil
+		}
+		clause, err := p.clause()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMatchmakerQueryInvalid, err.Error())
+		}
+		q.clauses = append(q.clauses, clause)
+	}
+}
+
+// String returns the query as it was given to ParseMatchmakerQuery.
+func (q *MatchmakerQuery) String() string {
+	return q.query
+}
+
+// Matches returns true if the query matches the properties of the entry.
+func (q *MatchmakerQuery) Matches(entry MatchmakerEntry) bool {
+	return q.Evaluate(entry.GetProperties()).Matched
+}
+
+// Evaluate evaluates the query against a set of matchmaker entry properties. An empty query matches nothing.
+func (q *MatchmakerQuery) Evaluate(properties map[string]interface{}) *MatchmakerQueryResult {
+	result := &MatchmakerQueryResult{Clauses: make([]*MatchmakerQueryClauseResult, 0, len(q.clauses))}
+	if q.matchAll {
+		result.Matched, result.Score = true, 1
+		return result
+	}
+
+	var must, should, shouldMatched, mustNotMatched, mustUnmatched int
+	for _, clause := range q.clauses {
+		matched := clause.evaluate(properties)
+		result.Clauses = append(result.Clauses, &MatchmakerQueryClauseResult{
+			Clause:  clause.text,
+			Occur:   clause.occur,
+			Boost:   clause.boost,
+			Matched: matched,
+		})
+		switch clause.occur {
+		case MatchmakerQueryMust:
+			must++
+			if !matched {
+				mustUnmatched++
+			}
+		case MatchmakerQueryShould:
+			should++
+			if matched {
+				shouldMatched++
+			}
+		case MatchmakerQueryMustNot:
+			if matched {
+				mustNotMatched++
+			}
+		}
+		if matched && clause.occur != MatchmakerQueryMustNot {
+			result.Score += clause.boost
+		}
+	}
+
+	switch {
+	case len(q.clauses) == 0, mustUnmatched > 0, mustNotMatched > 0:
+		result.Matched = false
+	case must == 0 && should > 0:
+		result.Matched = shouldMatched > 0
+	default:
+		result.Matched = true
+	}
+	if !result.Matched {
+		result.Score = 0
+	}
+	return result
+}
+
+func (c *matchmakerQueryClause) evaluate(properties map[string]interface{}) bool {
+	if c.field == "" {
+		for _, value := range properties {
+			if c.match(value) {
+				return true
+			}
+		}
+		return false
+	}
+	name, ok := strings.CutPrefix(c.field, matchmakerQueryPropertiesPrefix)
+	if !ok {
+		return false
+	}
+	value, ok := properties[name]
+	return ok && c.match(value)
+}
+
+type matchmakerQueryParser struct {
+	input string
+	pos   int
+}
+
+func (p *matchmakerQueryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *matchmakerQueryParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *matchmakerQueryParser) skipSpace() {
+	for !p.done() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// isTermEnd returns true for the unescaped characters that end a bare term.
+func isTermEnd(r rune) bool {
+	return unicode.IsSpace(r) || r == ':' || r == '^' || r == '~'
+}
+
+// term reads a bare term up to the next unescaped space, colon, boost or fuzziness marker. It returns the term with
+// escapes removed and, if the term contains unescaped wildcards, a regular expression matching the term.
+func (p *matchmakerQueryParser) term() (literal, pattern string, err error) {
+	var l, re strings.Builder
+	wildcard := false
+	for !p.done() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if isTermEnd(r) {
+			break
+		}
+		p.pos += size
+		switch r {
+		case '\\':
+			if p.done() {
+				return "", "", fmt.Errorf("unterminated escape at end of query")
+			}
+			r, size = utf8.DecodeRuneInString(p.input[p.pos:])
+			p.pos += size
+		case '*':
+			wildcard = true
+			l.WriteRune(r)
+			re.WriteString(".*")
+			continue
+		case '?':
+			wildcard = true
+			l.WriteRune(r)
+			re.WriteString(".")
+			continue
+		case '"', '/', '(', ')', '[', ']', '{', '}':
+			return "", "", fmt.Errorf("unexpected %q at position %v", r, p.pos-size)
+		}
+		l.WriteRune(r)
+		re.WriteString(regexp.QuoteMeta(string(r)))
+	}
+	if l.Len() == 0 {
+		return "", "", fmt.Errorf("expected term at position %v", p.pos)
+	}
+	if !wildcard {
+		return l.String(), "", nil
+	}
+	return l.String(), re.String(), nil
+}
+
+// delimited reads a value enclosed in the delimiter, such as a quoted phrase or a regular expression. As in Bleve, a
+// backslash escapes the character after it, so neither an escaped delimiter nor one after an escaped backslash ends
+// the value. Phrases keep the escaped character only, and regular expressions keep the backslash as well, except
+// before the delimiter, so that escapes such as \d keep their meaning.
+func (p *matchmakerQueryParser) delimited(delimiter byte) (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == delimiter:
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			escaped := p.input[p.pos]
+			p.pos++
+			if delimiter == '/' && escaped != delimiter {
+				b.WriteByte(c)
+			}
+			b.WriteByte(escaped)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated %q at position %v", delimiter, start)
+}
+
+func (p *matchmakerQueryParser) number() (float64, error) {
+	start := p.pos
+	var text string
+	if p.peek() == '"' {
+		var err error
+		if text, err = p.delimited('"'); err != nil {
+			return 0, err
+		}
+	} else {
+		for !p.done() {
+			r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+			if unicode.IsSpace(r) || r == '^' {
+				break
+			}
+			p.pos += size
+		}
+		text = p.input[start:p.pos]
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, fmt.Errorf("expected number at position %v", start)
+	}
+	return value, nil
+}
+
+func (p *matchmakerQueryParser) clause() (*matchmakerQueryClause, error) {
+	start := p.pos
+	clause := &matchmakerQueryClause{occur: MatchmakerQueryShould, boost: 1}
+	switch p.peek() {
+	case '+':
+		clause.occur = MatchmakerQueryMust
+		p.pos++
+	case '-':
+		clause.occur = MatchmakerQueryMustNot
+		p.pos++
+	}
+
+	if c := p.peek(); c != '"' && c != '/' && c != '>' && c != '<' {
+		literal, pattern, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ':' {
+			p.setTerm(clause, literal, pattern)
+			return clause, p.finish(clause, start)
+		}
+		if pattern != "" {
+			return nil, fmt.Errorf("invalid field name %q", literal)
+		}
+		clause.field = literal
+		p.pos++
+	}
+
+	switch c := p.peek(); c {
+	case '"':
+		phrase, err := p.delimited('"')
+		if err != nil {
+			return nil, err
+		}
+		clause.match = func(value interface{}) bool {
+			s, ok := stringValue(value)
+			return ok && s == phrase
+		}
+	case '/':
+		pattern, err := p.delimited('/')
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err.Error())
+		}
+		clause.match = func(value interface{}) bool {
+			s, ok := stringValue(value)
+			return ok && re.MatchString(s)
+		}
+	case '>', '<':
+		p.pos++
+		inclusive := p.peek() == '='
+		if inclusive {
+			p.pos++
+		}
+		bound, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		clause.match = func(value interface{}) bool {
+			n, ok := numberValue(value)
+			switch {
+			case !ok:
+				return false
+			case c == '>' && inclusive:
+				return n >= bound
+			case c == '>':
+				return n > bound
+			case inclusive:
+				return n <= bound
+			default:
+				return n < bound
+			}
+		}
+	default:
+		literal, pattern, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		p.setTerm(clause, literal, pattern)
+	}
+	return clause, p.finish(clause, start)
+}
+
+// setTerm sets the clause to match a bare term, which is a wildcard if it has a pattern, and may be followed by a
+// fuzziness marker.
+func (p *matchmakerQueryParser) setTerm(clause *matchmakerQueryClause, term, pattern string) {
+	switch {
+	case p.peek() == '~':
+		p.pos++
+		fuzziness := 1
+		if start := p.pos; !p.done() && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+			for !p.done() && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+				p.pos++
+			}
+			fuzziness, _ = strconv.Atoi(p.input[start:p.pos])
+		}
+		clause.match = func(value interface{}) bool {
+			s, ok := stringValue(value)
+			return ok && levenshtein(s, term) <= fuzziness
+		}
+	case pattern == ".*":
+		clause.match = func(value interface{}) bool {
+			return true
+		}
+	case pattern != "":
+		re := regexp.MustCompile("^(?s:" + pattern + ")$")
+		clause.match = func(value interface{}) bool {
+			s, ok := stringValue(value)
+			return ok && re.MatchString(s)
+		}
+	default:
+		number, err := strconv.ParseFloat(term, 64)
+		isNumber := err == nil
+		clause.match = func(value interface{}) bool {
+			if s, ok := stringValue(value); ok {
+				return s == term
+			}
+			n, ok := numberValue(value)
+			return ok && isNumber && n == number
+		}
+	}
+}
+
+// finish reads the optional boost of a clause and records the clause text.
+func (p *matchmakerQueryParser) finish(clause *matchmakerQueryClause, start int) error {
+	if p.peek() == '^' {
+		p.pos++
+		boostStart := p.pos
+		for !p.done() && (p.input[p.pos] == '.' || p.input[p.pos] >= '0' && p.input[p.pos] <= '9') {
+			p.pos++
+		}
+		boost, err := strconv.ParseFloat(p.input[boostStart:p.pos], 64)
+		if err != nil {
+			return fmt.Errorf("expected boost at position %v", boostStart)
+		}
+		clause.boost = boost
+	}
+	if r, _ := utf8.DecodeRuneInString(p.input[p.pos:]); !p.done() && !unicode.IsSpace(r) {
+		return fmt.Errorf("unexpected %q at position %v", r, p.pos)
+	}
+	clause.text = p.input[start:p.pos]
+	return nil
+}
+
+// stringValue returns the value as it is indexed for exact, wildcard, regular expression and fuzzy matches.
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// numberValue returns the value as it is indexed for numeric term and range matches.
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchmakerQueryEvaluate(t *testing.T) {
+	properties := map[string]interface{}{
+		"region": "europe",
+		"mode":   "ranked",
+		"name":   "pro player",
+		"skill":  float64(15),
+		"party":  true,
+		"slash":  `a\`,
+	}
+	tests := []struct {
+		query   string
+		matched bool
+		score   float64
+	}{
+		{"*", true, 1},
+		{"", false, 0},
+		{"+properties.region:europe", true, 1},
+		{"+properties.region:asia", false, 0},
+		{"properties.region:asia properties.mode:ranked^3", true, 3},
+		{"properties.region:asia properties.mode:casual", false, 0},
+		{"+properties.region:europe properties.mode:casual", true, 1},
+		{"+properties.region:europe -properties.mode:ranked", false, 0},
+		{"-properties.mode:casual", true, 0},
+		{"+properties.skill:>=10 +properties.skill:<20", true, 2},
+		{"+properties.skill:>15", false, 0},
+		{"+properties.skill:15", true, 1},
+		{`+properties.skill:<="15.5"`, true, 1},
+		{`+properties.name:"pro player"`, true, 1},
+		{`+properties.name:pro\ player`, true, 1},
+		{"+properties.region:eu*", true, 1},
+		{"+properties.region:eur?pe", true, 1},
+		{`+properties.region:eu\*`, false, 0},
+		{"+properties.region:/eu.+/", true, 1},
+		{"+properties.region:/eu/", false, 0},
+		{"+properties.region:earope~", true, 1},
+		{"+properties.region:eaarope~", false, 0},
+		{"+properties.region:eaarope~2", true, 1},
+		{"+properties.party:true", true, 1},
+		{"+properties.missing:*", false, 0},
+		{"+region:europe", false, 0},
+		{"+europe", true, 1},
+		{`+properties.slash:"a\\"`, true, 1},
+		{`+properties.slash:"a\\" -properties.region:europe`, false, 0},
+		{`+properties.slash:/a\\/ -properties.region:europe`, false, 0},
+		{`+properties.slash:/a\\/`, true, 1},
+	}
+	for _, test := range tests {
+		query, err := ParseMatchmakerQuery(test.query)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.query, err)
+			continue
+		}
+		result := query.Evaluate(properties)
+		if result.Matched != test.matched || result.Score != test.score {
+			t.Errorf("%q: expected matched %v with score %v, got %v", test.query, test.matched, test.score, result)
+		}
+	}
+}
+
+func TestMatchmakerQueryInvalid(t *testing.T) {
+	for _, query := range []string{
+		`properties.name:"unterminated`,
+		"properties.region:/[/",
+		"properties.skill:>high",
+		"properties.region:",
+		"properties.region:europe^",
+		`properties.region:europe\`,
+		"properties.region:(europe)",
+	} {
+		if _, err := ParseMatchmakerQuery(query); !errors.Is(err, ErrMatchmakerQueryInvalid) {
+			t.Errorf("%q: expected invalid query error, got %v", query, err)
+		}
+	}
+}
+
+func TestMatchmakerQueryResultString(t *testing.T) {
+	query, err := ParseMatchmakerQuery("+properties.region:europe  -properties.mode:ranked properties.skill:>10^2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `not matched
+  MUST +properties.region:europe: matched
+  MUST_NOT -properties.mode:ranked: matched
+  SHOULD properties.skill:>10^2: matched`
+	if result := query.Evaluate(map[string]interface{}{"region": "europe", "mode": "ranked", "skill": 12}).String(); result != expected {
+		t.Fatalf("unexpected explanation:\n%v", result)
+	}
+}
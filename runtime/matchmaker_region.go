@@ -0,0 +1,86 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"maps"
+	"slices"
+)
+
+/*
+MatchmakerRegion returns the region the matchmaker chooses for entries matched together: the region every entry with
+latencies has a latency for, no higher than maxLatency, with the lowest worst latency among the entries. Ties go to
+the region that sorts first. A maxLatency of 0 or less places no limit on the latency.
+
+Entries without latencies match in any region. If no entry has latencies the region is empty and ok is true, and if
+the entries share no region under maxLatency ok is false, and the matchmaker does not match them together.
+
+	region, ok := runtime.MatchmakerRegion(entries, 80)
+*/
+func MatchmakerRegion(entries []MatchmakerEntry, maxLatency float32) (region string, ok bool) {
+	regions := make(map[string]bool)
+	for _, entry := range entries {
+		for r := range entry.GetLatencies() {
+			regions[r] = true
+		}
+	}
+	if len(regions) == 0 {
+		return "", true
+	}
+
+	var best float32
+	for _, r := range slices.Sorted(maps.Keys(regions)) {
+		worst, shared := float32(0), true
+		for _, entry := range entries {
+			latencies := entry.GetLatencies()
+			if len(latencies) == 0 {
+				continue
+			}
+			latency, found := latencies[r]
+			if !found || (maxLatency > 0 && latency > maxLatency) {
+				shared = false
+				break
+			}
+			worst = max(worst, latency)
+		}
+		if shared && (!ok || worst < best) {
+			region, best, ok = r, worst, true
+		}
+	}
+	return region, ok
+}
+
+// MatchmakerFleetLatencies returns the latencies of the entries in the form FleetManager.Create accepts, one for each
+// user and region, sorted by region for each user. If region is set, only latencies to that region are returned.
+func MatchmakerFleetLatencies(entries []MatchmakerEntry, region string) []FleetUserLatencies {
+	latencies := make([]FleetUserLatencies, 0, len(entries))
+	for _, entry := range entries {
+		presence := entry.GetPresence()
+		if presence == nil {
+			continue
+		}
+		for _, r := range slices.Sorted(maps.Keys(entry.GetLatencies())) {
+			if region != "" && r != region {
+				continue
+			}
+			latencies = append(latencies, FleetUserLatencies{
+				UserId:                presence.GetUserId(),
+				LatencyInMilliseconds: entry.GetLatencies()[r],
+				RegionIdentifier:      r,
+			})
+		}
+	}
+	return latencies
+}
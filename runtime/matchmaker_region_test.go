@@ -0,0 +1,61 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-common/runtime/runtimetest"
+)
+
+func TestMatchmakerRegion(t *testing.T) {
+	entry := func(latencies map[string]float32) runtime.MatchmakerEntry {
+		return &runtimetest.MatchmakerEntry{Presence: runtimetest.NewPresence("user", "user"), Latencies: latencies}
+	}
+	tests := []struct {
+		name       string
+		entries    []runtime.MatchmakerEntry
+		maxLatency float32
+		region     string
+		ok         bool
+	}{
+		{"no latencies", []runtime.MatchmakerEntry{entry(nil), entry(nil)}, 50, "", true},
+		{"lowest worst latency", []runtime.MatchmakerEntry{entry(map[string]float32{"eu": 20, "us": 90}), entry(map[string]float32{"eu": 60, "us": 40})}, 0, "eu", true},
+		{"threshold", []runtime.MatchmakerEntry{entry(map[string]float32{"eu": 20, "us": 90}), entry(map[string]float32{"eu": 60, "us": 40})}, 50, "", false},
+		{"unshared region", []runtime.MatchmakerEntry{entry(map[string]float32{"eu": 20}), entry(map[string]float32{"us": 20})}, 0, "", false},
+		{"entry without latencies", []runtime.MatchmakerEntry{entry(map[string]float32{"us": 30}), entry(nil)}, 50, "us", true},
+		{"tie", []runtime.MatchmakerEntry{entry(map[string]float32{"us": 30, "eu": 30})}, 0, "eu", true},
+	}
+	for _, test := range tests {
+		if region, ok := runtime.MatchmakerRegion(test.entries, test.maxLatency); region != test.region || ok != test.ok {
+			t.Errorf("%v: expected %q, %v, got %q, %v", test.name, test.region, test.ok, region, ok)
+		}
+	}
+}
+
+func TestMatchmakerFleetLatencies(t *testing.T) {
+	entries := []runtime.MatchmakerEntry{
+		&runtimetest.MatchmakerEntry{Presence: runtimetest.NewPresence("alice", "alice"), Latencies: map[string]float32{"us": 40, "eu": 20}},
+		&runtimetest.MatchmakerEntry{Presence: runtimetest.NewPresence("bob", "bob")},
+	}
+	if latencies := runtime.MatchmakerFleetLatencies(entries, ""); len(latencies) != 2 || latencies[0].RegionIdentifier != "eu" || latencies[1].LatencyInMilliseconds != 40 {
+		t.Fatalf("unexpected latencies %+v", latencies)
+	}
+	latencies := runtime.MatchmakerFleetLatencies(entries, "us")
+	if len(latencies) != 1 || latencies[0] != (runtime.FleetUserLatencies{UserId: "alice", LatencyInMilliseconds: 40, RegionIdentifier: "us"}) {
+		t.Fatalf("unexpected latencies for region %+v", latencies)
+	}
+}
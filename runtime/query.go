@@ -0,0 +1,204 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"cmp"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// QueryClause is a single condition on a field, created by QueryTerm, QueryPhrase or one of the numeric comparison
+// functions, and added to a QueryBuilder.
+type QueryClause struct {
+	field   string
+	value   string
+	boost   float64
+	boosted bool
+	err     error
+}
+
+// QueryTerm returns a clause matching fields equal to the value. Characters with a meaning in the query syntax are
+// escaped, so the value can come from user input.
+func QueryTerm(field, value string) *QueryClause {
+	return &QueryClause{field: field, value: escapeQueryTerm(value)}
+}
+
+// QueryPhrase returns a clause matching fields containing the phrase. Backslashes and quotes in the phrase are
+// escaped, so the phrase can come from user input.
+func QueryPhrase(field, phrase string) *QueryClause {
+	return &QueryClause{field: field, value: `"` + queryPhraseEscaper.Replace(phrase) + `"`}
+}
+
+// queryPhraseEscaper escapes the characters with a meaning inside a quoted phrase, backslash first, in a single pass.
+var queryPhraseEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// QueryGreater returns a clause matching numeric fields greater than the value. The value must be finite, or the
+// clause is invalid and the query it is added to fails with ErrQueryNumberInvalid.
+func QueryGreater(field string, value float64) *QueryClause {
+	return queryComparison(field, ">", value)
+}
+
+// QueryGreaterOrEqual returns a clause matching numeric fields greater than or equal to the value. The value must be
+// finite, as for QueryGreater.
+func QueryGreaterOrEqual(field string, value float64) *QueryClause {
+	return queryComparison(field, ">=", value)
+}
+
+// QueryLess returns a clause matching numeric fields less than the value. The value must be finite, as for
+// QueryGreater.
+func QueryLess(field string, value float64) *QueryClause {
+	return queryComparison(field, "<", value)
+}
+
+// QueryLessOrEqual returns a clause matching numeric fields less than or equal to the value. The value must be
+// finite, as for QueryGreater.
+func QueryLessOrEqual(field string, value float64) *QueryClause {
+	return queryComparison(field, "<=", value)
+}
+
+func queryComparison(field, operator string, value float64) *QueryClause {
+	if !isFiniteQueryNumber(value) {
+		return &QueryClause{field: field, err: ErrQueryNumberInvalid}
+	}
+	return &QueryClause{field: field, value: operator + formatQueryNumber(value)}
+}
+
+// Boost returns a copy of the clause with the boost set, which multiplies its contribution to the score of results it
+// matches. The boost is written to the query even if it is 0 or 1, and must be finite, as for QueryGreater.
+func (c *QueryClause) Boost(boost float64) *QueryClause {
+	boosted := *c
+	boosted.boost, boosted.boosted = boost, true
+	if !isFiniteQueryNumber(boost) {
+		boosted.err = cmp.Or(boosted.err, ErrQueryNumberInvalid)
+	}
+	return &boosted
+}
+
+// String returns the clause in the query syntax, without an occurrence prefix.
+func (c *QueryClause) String() string {
+	var b strings.Builder
+	if c.field != "" {
+		b.WriteString(escapeQueryTerm(c.field))
+		b.WriteByte(':')
+	}
+	b.WriteString(c.value)
+	if c.boosted {
+		b.WriteByte('^')
+		b.WriteString(formatQueryNumber(c.boost))
+	}
+	return b.String()
+}
+
+/*
+QueryBuilder builds query strings for MatchList and ListMatchesRequest.query, StorageIndexList, PartyList and
+MatchmakerAdd, escaping terms so that values from user input cannot change the structure of the query. Field names
+are given in full, with the "label.", "value." or "properties." prefix the query expects.
+
+	query, err := runtime.NewQueryBuilder().
+		Must(runtime.QueryTerm("label.mode", mode)).
+		MustRange("label.skill", 10, 20).
+		MustNot(runtime.QueryTerm("label.region", "us")).
+		Should(runtime.QueryTerm("label.map", "docks").Boost(2)).
+		Build()
+	if err != nil {
+		return err
+	}
+	matches, err := nk.MatchList(ctx, 10, true, "", nil, nil, query)
+
+The query syntax has no grouping, so a numeric range with both bounds can only be required, with MustRange.
+*/
+type QueryBuilder struct {
+	clauses []string
+	err     error
+}
+
+// NewQueryBuilder returns an empty query builder.
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+func (b *QueryBuilder) add(prefix string, clauses []*QueryClause) *QueryBuilder {
+	for _, clause := range clauses {
+		if clause.err != nil {
+			b.err = cmp.Or(b.err, clause.err)
+			continue
+		}
+		b.clauses = append(b.clauses, prefix+clause.String())
+	}
+	return b
+}
+
+// Must adds clauses that results are required to match.
+func (b *QueryBuilder) Must(clauses ...*QueryClause) *QueryBuilder {
+	return b.add("+", clauses)
+}
+
+// Should adds optional clauses, which raise the score of results that match them. If the query has no required
+// clauses, results must match at least one optional clause.
+func (b *QueryBuilder) Should(clauses ...*QueryClause) *QueryBuilder {
+	return b.add("", clauses)
+}
+
+// MustNot adds clauses that results are required not to match.
+func (b *QueryBuilder) MustNot(clauses ...*QueryClause) *QueryBuilder {
+	return b.add("-", clauses)
+}
+
+// MustRange requires results to have a numeric field between min and max, inclusive.
+func (b *QueryBuilder) MustRange(field string, min, max float64) *QueryBuilder {
+	return b.Must(QueryGreaterOrEqual(field, min), QueryLessOrEqual(field, max))
+}
+
+// String returns the query, which is empty if no clauses were added. Invalid clauses are left out, so Build should be
+// used unless the clauses are known to be valid.
+func (b *QueryBuilder) String() string {
+	return strings.Join(b.clauses, " ")
+}
+
+// Build returns the query, or the error of the first invalid clause that was added.
+func (b *QueryBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	return b.String(), nil
+}
+
+// isQuerySyntax returns true for the characters that have a meaning in the query syntax.
+func isQuerySyntax(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`+-=&|><!(){}[]^"~*?:\/`, r)
+}
+
+// escapeQueryTerm escapes the characters in the term that have a meaning in the query syntax.
+func escapeQueryTerm(term string) string {
+	var b strings.Builder
+	for _, r := range term {
+		if isQuerySyntax(r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isFiniteQueryNumber(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func formatQueryNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	query := NewQueryBuilder().
+		Must(QueryTerm("label.mode", "ranked")).
+		MustRange("label.skill", 10, 20.5).
+		MustNot(QueryTerm("label.region", "us east")).
+		Should(QueryPhrase("label.name", `say "hi"`).Boost(2.5), QueryLess("label.size", -1)).
+		String()
+	expected := `+label.mode:ranked +label.skill:>=10 +label.skill:<=20.5 -label.region:us\ east label.name:"say \"hi\""^2.5 label.size:<-1`
+	if query != expected {
+		t.Fatalf("expected %v, got %v", expected, query)
+	}
+	if query := NewQueryBuilder().String(); query != "" {
+		t.Fatalf("expected empty query, got %v", query)
+	}
+}
+
+func TestQueryBuilderInvalidNumbers(t *testing.T) {
+	for _, clause := range []*QueryClause{
+		QueryGreater("label.skill", math.NaN()),
+		QueryLess("label.skill", math.Inf(1)),
+		QueryLessOrEqual("label.skill", math.Inf(-1)),
+		QueryTerm("label.mode", "ranked").Boost(math.NaN()),
+	} {
+		query, err := NewQueryBuilder().Must(QueryTerm("label.region", "eu")).Should(clause).Build()
+		if !errors.Is(err, ErrQueryNumberInvalid) || query != "" {
+			t.Errorf("%q: expected invalid number error, got %q %v", clause, query, err)
+		}
+	}
+	if _, err := NewQueryBuilder().MustRange("label.skill", 0, math.Inf(1)).Build(); !errors.Is(err, ErrQueryNumberInvalid) {
+		t.Errorf("expected invalid number error for an unbounded range, got %v", err)
+	}
+	if query, err := NewQueryBuilder().MustRange("label.skill", 10, 20).Build(); err != nil || query != "+label.skill:>=10 +label.skill:<=20" {
+		t.Errorf("unexpected query %q %v", query, err)
+	}
+}
+
+func TestQueryClauseBoost(t *testing.T) {
+	clause := QueryTerm("label.mode", "ranked")
+	boosted := clause.Boost(0)
+	if clause.String() != "label.mode:ranked" {
+		t.Fatalf("expected boosting to leave the clause unchanged, got %v", clause)
+	}
+	if boosted.String() != "label.mode:ranked^0" {
+		t.Fatalf("expected a boost of 0 to be written, got %v", boosted)
+	}
+	if boosted := clause.Boost(1); boosted.String() != "label.mode:ranked^1" {
+		t.Fatalf("expected a boost of 1 to be written, got %v", boosted)
+	}
+}
+
+func TestQueryBuilderEscaping(t *testing.T) {
+	properties := map[string]interface{}{
+		"region": "europe",
+		"mode":   "ranked",
+		"name":   `pro "player"`,
+		"skill":  float64(15),
+	}
+	tests := []struct {
+		builder *QueryBuilder
+		matched bool
+		score   float64
+	}{
+		{NewQueryBuilder().Must(QueryTerm("properties.region", "europe")), true, 1},
+		{NewQueryBuilder().Must(QueryTerm("properties.region", "eu*")), false, 0},
+		{NewQueryBuilder().Must(QueryTerm("properties.region", "europe -properties.mode:ranked")), false, 0},
+		{NewQueryBuilder().Must(QueryTerm("properties.name", `pro "player"`)), true, 1},
+		{NewQueryBuilder().Must(QueryPhrase("properties.name", `pro "player"`)), true, 1},
+		{NewQueryBuilder().MustNot(QueryTerm("properties.mode", "(ranked)")), true, 0},
+		{NewQueryBuilder().MustRange("properties.skill", 15, 15), true, 2},
+		{NewQueryBuilder().Must(QueryGreater("properties.skill", 15)), false, 0},
+		{NewQueryBuilder().Should(QueryTerm("properties.mode", "casual"), QueryTerm("properties.region", "europe").Boost(3)), true, 3},
+	}
+	for _, test := range tests {
+		query, err := ParseMatchmakerQuery(test.builder.String())
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.builder, err)
+			continue
+		}
+		result := query.Evaluate(properties)
+		if result.Matched != test.matched || result.Score != test.score {
+			t.Errorf("%q: expected matched %v with score %v, got %v", test.builder, test.matched, test.score, result)
+		}
+	}
+}
+
+// blevePhrase reads the quoted phrase at the start of query following Bleve's query string rules, where a backslash
+// escapes the character after it and only an unescaped quote ends the phrase. It returns the phrase and the rest of
+// the query after the closing quote.
+func blevePhrase(t *testing.T, query string) (string, string) {
+	t.Helper()
+	if !strings.HasPrefix(query, `"`) {
+		t.Fatalf("expected phrase at start of %q", query)
+	}
+	var b strings.Builder
+	for i := 1; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\\' && i+1 < len(query):
+			i++
+			b.WriteByte(query[i])
+		case c == '"':
+			return b.String(), query[i+1:]
+		default:
+			b.WriteByte(c)
+		}
+	}
+	t.Fatalf("unterminated phrase in %q", query)
+	return "", ""
+}
+
+func TestQueryPhraseEscaping(t *testing.T) {
+	for _, phrase := range []string{
+		`a\" +properties.admin:true x:\`,
+		`\\"`,
+		`trailing \`,
+		`say "hi"`,
+	} {
+		query := NewQueryBuilder().Must(QueryPhrase("properties.name", phrase)).String()
+		value, ok := strings.CutPrefix(query, "+properties.name:")
+		if !ok {
+			t.Fatalf("unexpected query %q", query)
+		}
+		if parsed, rest := blevePhrase(t, value); parsed != phrase || rest != "" {
+			t.Errorf("%q: expected a single phrase under Bleve rules, got %q followed by %q", query, parsed, rest)
+		}
+
+		parsed, err := ParseMatchmakerQuery(query)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", query, err)
+			continue
+		}
+		if result := parsed.Evaluate(map[string]interface{}{"name": phrase, "admin": false}); !result.Matched || result.Score != 1 {
+			t.Errorf("%q: expected only the phrase to match, got %v", query, result)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"cmp"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultRatingTau is the Glicko-2 system constant used by rulesets that do not set one. Smaller values limit how
+	// quickly the volatility of a rating can change.
+	DefaultRatingTau = 0.5
+	// DefaultRating is the rating of a user before their first rated match.
+	DefaultRating = 1500.0
+	// DefaultRatingDeviation is the deviation of a rating before its first rated match, which is also the highest
+	// deviation a rating can decay to.
+	DefaultRatingDeviation = 350.0
+	// DefaultRatingVolatility is the volatility of a rating before its first rated match.
+	DefaultRatingVolatility = 0.06
+
+	// glicko2Scale converts between the Glicko rating scale and the Glicko-2 scale the calculations are made in.
+	glicko2Scale = 173.7178
+	// glicko2Epsilon is the convergence tolerance of the volatility iteration.
+	glicko2Epsilon = 0.000001
+	// glicko2MinVariance bounds the sum the estimated variance is the inverse of, which underflows to 0 when the
+	// outcome against every opponent is certain at the precision of a float64.
+	glicko2MinVariance = 1e-12
+)
+
+// RatingRuleset configures an independent pool of ratings, registered with RegisterRatingRuleset. Zero values use the
+// Default* constants, and a zero DecayPeriod disables decay.
+type RatingRuleset struct {
+	ID                string
+	Tau               float64
+	InitialRating     float64
+	InitialDeviation  float64
+	InitialVolatility float64
+	// DecayPeriod is the rating period: the deviation of a rating grows by one period of its volatility for each
+	// DecayPeriod that passes without a rated match, up to the initial deviation.
+	DecayPeriod time.Duration
+	// MatchmakerProperty, if set, is the numeric property the server sets to the rating of the user on the
+	// matchmaker tickets they add, unless the ticket already sets it. On party tickets the rating of each member is
+	// set in the MemberNumericProperties of the ticket.
+	MatchmakerProperty string
+}
+
+func (r *RatingRuleset) tau() float64 {
+	return cmp.Or(r.Tau, DefaultRatingTau)
+}
+
+func (r *RatingRuleset) initialDeviation() float64 {
+	return cmp.Or(r.InitialDeviation, DefaultRatingDeviation)
+}
+
+// Rating is the Glicko-2 rating of a user in a ruleset. UpdateTime is in seconds since the Unix epoch, and is 0 for a
+// rating that has not been updated by a rated match.
+type Rating struct {
+	UserID     string
+	Ruleset    string
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+	Matches    int64
+	UpdateTime int64
+}
+
+// RatingLedgerItem records a change to a rating made by RatingsUpdate, with the rating after the change.
+type RatingLedgerItem struct {
+	ID         string
+	UserID     string
+	Ruleset    string
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+	Change     float64
+	Metadata   map[string]interface{}
+	CreateTime int64
+}
+
+// NewRating returns the rating of a user in the ruleset before their first rated match.
+func NewRating(ruleset *RatingRuleset, userID string) *Rating {
+	return &Rating{
+		UserID:     userID,
+		Ruleset:    ruleset.ID,
+		Rating:     cmp.Or(ruleset.InitialRating, DefaultRating),
+		Deviation:  ruleset.initialDeviation(),
+		Volatility: cmp.Or(ruleset.InitialVolatility, DefaultRatingVolatility),
+	}
+}
+
+// DecayRating returns a copy of the rating with its deviation grown for the rating periods that have passed between
+// its update time and now, up to the initial deviation of the ruleset.
+func DecayRating(ruleset *RatingRuleset, rating *Rating, now time.Time) *Rating {
+	decayed := *rating
+	if ruleset.DecayPeriod <= 0 || rating.UpdateTime == 0 {
+		return &decayed
+	}
+	periods := float64(now.Sub(time.Unix(rating.UpdateTime, 0))) / float64(ruleset.DecayPeriod)
+	if periods <= 0 {
+		return &decayed
+	}
+	phi := rating.Deviation / glicko2Scale
+	phi = math.Sqrt(phi*phi + periods*rating.Volatility*rating.Volatility)
+	decayed.Deviation = math.Min(phi*glicko2Scale, ruleset.initialDeviation())
+	return &decayed
+}
+
+/*
+UpdateRatings returns the ratings of the players of a rated match after it, using the Glicko-2 system with the match as
+a single rating period. Teams hold the ratings of their players before the match and ranks the place of each team,
+where a lower rank is better and teams with the same rank drew. Each player is rated against every other team as a
+single opponent, with the mean rating and root mean square deviation of its players.
+
+	// Alice and Bob beat Carol and Dave.
+	updated, err := runtime.UpdateRatings(ruleset, [][]*runtime.Rating{{alice, bob}, {carol, dave}}, []int{1, 2})
+
+The returned ratings have the same layout as teams, with Matches incremented and UpdateTime unchanged.
+ErrRatingUpdateInvalid is returned if there are fewer than two teams, an empty team, not one rank per team, a nil
+rating or one without a positive volatility, or the same user ID in more than one rating. Ratings without a user ID
+are not checked for duplicates.
+*/
+func UpdateRatings(ruleset *RatingRuleset, teams [][]*Rating, ranks []int) ([][]*Rating, error) {
+	if len(teams) < 2 || len(ranks) != len(teams) {
+		return nil, ErrRatingUpdateInvalid
+	}
+	type opponent struct {
+		mu, phi float64
+	}
+	opponents := make([]opponent, len(teams))
+	userIDs := make(map[string]struct{})
+	for i, team := range teams {
+		if len(team) == 0 {
+			return nil, ErrRatingUpdateInvalid
+		}
+		for _, rating := range team {
+			if rating == nil || rating.Volatility <= 0 {
+				return nil, ErrRatingUpdateInvalid
+			}
+			if rating.UserID != "" {
+				if _, ok := userIDs[rating.UserID]; ok {
+					return nil, ErrRatingUpdateInvalid
+				}
+				userIDs[rating.UserID] = struct{}{}
+			}
+			opponents[i].mu += (rating.Rating - DefaultRating) / glicko2Scale
+			opponents[i].phi += (rating.Deviation / glicko2Scale) * (rating.Deviation / glicko2Scale)
+		}
+		opponents[i].mu /= float64(len(team))
+		opponents[i].phi = math.Sqrt(opponents[i].phi / float64(len(team)))
+	}
+
+	updated := make([][]*Rating, len(teams))
+	for i, team := range teams {
+		updated[i] = make([]*Rating, 0, len(team))
+		for _, rating := range team {
+			mu := (rating.Rating - DefaultRating) / glicko2Scale
+			phi := rating.Deviation / glicko2Scale
+
+			var variance, improvement float64
+			for j, o := range opponents {
+				if j == i {
+					continue
+				}
+				score := 0.5
+				if ranks[i] < ranks[j] {
+					score = 1
+				} else if ranks[i] > ranks[j] {
+					score = 0
+				}
+				g := 1 / math.Sqrt(1+3*o.phi*o.phi/(math.Pi*math.Pi))
+				expected := 1 / (1 + math.Exp(-g*(mu-o.mu)))
+				variance += g * g * expected * (1 - expected)
+				improvement += g * (score - expected)
+			}
+			variance = 1 / math.Max(variance, glicko2MinVariance)
+			delta := variance * improvement
+
+			volatility := glicko2Volatility(ruleset.tau(), phi, rating.Volatility, variance, delta)
+			phiStar := math.Sqrt(phi*phi + volatility*volatility)
+			phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+			mu += phi * phi * improvement
+
+			r := *rating
+			r.Rating = mu*glicko2Scale + DefaultRating
+			r.Deviation = phi * glicko2Scale
+			r.Volatility = volatility
+			r.Matches++
+			updated[i] = append(updated[i], &r)
+		}
+	}
+	return updated, nil
+}
+
+// glicko2Volatility returns the new volatility of a rating, found with the Illinois algorithm as in step 5 of the
+// Glicko-2 system.
+func glicko2Volatility(tau, phi, sigma, variance, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + variance + ex
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	lower := a
+	var upper float64
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		upper = a - k*tau
+	}
+
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > glicko2Epsilon {
+		c := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fC := f(c)
+		if fC*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+		upper, fUpper = c, fC
+	}
+	return math.Exp(lower / 2)
+}
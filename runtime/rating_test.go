@@ -0,0 +1,121 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUpdateRatings(t *testing.T) {
+	// The example from the Glicko-2 paper: the player beats the first opponent and loses to the other two.
+	ruleset := &RatingRuleset{ID: "ranked"}
+	player := &Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	teams := [][]*Rating{
+		{player},
+		{{Rating: 1400, Deviation: 30, Volatility: 0.06}},
+		{{Rating: 1550, Deviation: 100, Volatility: 0.06}},
+		{{Rating: 1700, Deviation: 300, Volatility: 0.06}},
+	}
+	updated, err := UpdateRatings(ruleset, teams, []int{2, 3, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := updated[0][0]; math.Abs(r.Rating-1464.06) > 0.01 || math.Abs(r.Deviation-151.52) > 0.01 || math.Abs(r.Volatility-0.05999) > 0.00001 || r.Matches != 1 {
+		t.Fatalf("unexpected rating %+v", r)
+	}
+	if player.Rating != 1500 || player.Matches != 0 {
+		t.Fatalf("expected the rating before the match to be unchanged, got %+v", player)
+	}
+
+	alice, bob := NewRating(ruleset, "alice"), NewRating(ruleset, "bob")
+	carol, dave := NewRating(ruleset, "carol"), NewRating(ruleset, "dave")
+	updated, err = UpdateRatings(ruleset, [][]*Rating{{alice, bob}, {carol, dave}}, []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated[0][0].Rating <= DefaultRating || updated[1][1].Rating >= DefaultRating || updated[0][1].Deviation >= DefaultRatingDeviation {
+		t.Fatalf("expected the winners to gain rating, got %+v", updated)
+	}
+	drawn, _ := UpdateRatings(ruleset, [][]*Rating{{alice}, {carol}}, []int{1, 1})
+	if drawn[0][0].Rating != DefaultRating {
+		t.Fatalf("expected a draw between equal ratings to leave them unchanged, got %+v", drawn[0][0])
+	}
+
+	for _, ranks := range [][]int{{1}, {1, 2, 3}} {
+		if _, err := UpdateRatings(ruleset, [][]*Rating{{alice}, {bob}}, ranks); !errors.Is(err, ErrRatingUpdateInvalid) {
+			t.Errorf("%v: expected invalid update error, got %v", ranks, err)
+		}
+	}
+	if _, err := UpdateRatings(ruleset, [][]*Rating{{alice}, {}}, []int{1, 2}); !errors.Is(err, ErrRatingUpdateInvalid) {
+		t.Errorf("expected invalid update error for an empty team, got %v", err)
+	}
+	if _, err := UpdateRatings(ruleset, [][]*Rating{{alice}, {nil}}, []int{1, 2}); !errors.Is(err, ErrRatingUpdateInvalid) {
+		t.Errorf("expected invalid update error for a nil rating, got %v", err)
+	}
+	if _, err := UpdateRatings(ruleset, [][]*Rating{{alice}, {{UserID: "erin", Rating: DefaultRating, Deviation: DefaultRatingDeviation}}}, []int{1, 2}); !errors.Is(err, ErrRatingUpdateInvalid) {
+		t.Errorf("expected invalid update error for a zero volatility, got %v", err)
+	}
+	for _, teams := range [][][]*Rating{{{alice, alice}, {bob}}, {{alice}, {bob, alice}}} {
+		if _, err := UpdateRatings(ruleset, teams, []int{1, 2}); !errors.Is(err, ErrRatingUpdateInvalid) {
+			t.Errorf("expected invalid update error for a duplicate user, got %v", err)
+		}
+	}
+}
+
+func TestUpdateRatingsCertainOutcome(t *testing.T) {
+	// The outcome is certain at float64 precision, so the variance sum underflows to 0 without a bound, and the
+	// volatility of an upset is left unchanged by the NaN comparisons that follow.
+	ruleset := &RatingRuleset{ID: "ranked"}
+	strong := &Rating{UserID: "strong", Rating: 100000, Deviation: 30, Volatility: 0.06}
+	weak := &Rating{UserID: "weak", Rating: 100, Deviation: 30, Volatility: 0.06}
+	for _, ranks := range [][]int{{1, 2}, {2, 1}} {
+		updated, err := UpdateRatings(ruleset, [][]*Rating{{strong}, {weak}}, ranks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range []*Rating{updated[0][0], updated[1][0]} {
+			if math.IsNaN(r.Rating) || math.IsInf(r.Rating, 0) || math.IsNaN(r.Deviation) || r.Deviation <= 0 || math.IsNaN(r.Volatility) || r.Volatility <= 0 {
+				t.Fatalf("%v: expected a finite rating, got %+v", ranks, r)
+			}
+		}
+		if ranks[0] == 1 && (math.Abs(updated[0][0].Rating-strong.Rating) > 0.01 || math.Abs(updated[1][0].Rating-weak.Rating) > 0.01) {
+			t.Fatalf("expected the certain outcome to leave the ratings unchanged, got %+v %+v", updated[0][0], updated[1][0])
+		}
+		if ranks[0] == 2 && (updated[0][0].Rating >= strong.Rating || updated[1][0].Rating <= weak.Rating || updated[1][0].Volatility <= weak.Volatility) {
+			t.Fatalf("expected the upset to move the ratings and raise the volatility, got %+v %+v", updated[0][0], updated[1][0])
+		}
+	}
+}
+
+func TestDecayRating(t *testing.T) {
+	ruleset := &RatingRuleset{ID: "ranked", DecayPeriod: 24 * time.Hour}
+	updated := time.Unix(1700000000, 0)
+	rating := &Rating{Rating: 1600, Deviation: 50, Volatility: 0.06, UpdateTime: updated.Unix()}
+
+	if decayed := DecayRating(ruleset, rating, updated); decayed.Deviation != 50 {
+		t.Fatalf("expected no decay without time passing, got %+v", decayed)
+	}
+	decayed := DecayRating(ruleset, rating, updated.Add(10*24*time.Hour))
+	expected := math.Sqrt(math.Pow(50/glicko2Scale, 2)+10*0.06*0.06) * glicko2Scale
+	if math.Abs(decayed.Deviation-expected) > 0.0001 || decayed.Rating != 1600 || rating.Deviation != 50 {
+		t.Fatalf("expected deviation %v after 10 periods, got %+v", expected, decayed)
+	}
+	if decayed := DecayRating(ruleset, rating, updated.Add(100000*24*time.Hour)); decayed.Deviation != DefaultRatingDeviation {
+		t.Fatalf("expected the deviation to be capped, got %+v", decayed)
+	}
+}
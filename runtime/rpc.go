@@ -0,0 +1,166 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// RpcCodec converts between RPC payloads and request and response values.
+type RpcCodec interface {
+	// Unmarshal decodes the payload into the value v points to.
+	Unmarshal(payload string, v interface{}) error
+	// Marshal encodes v as a payload.
+	Marshal(v interface{}) (string, error)
+}
+
+var (
+	// JSONRpcCodec encodes payloads as JSON.
+	JSONRpcCodec RpcCodec = jsonRpcCodec{}
+
+	// ProtoRpcCodec encodes payloads as base64 encoded protobuf messages. Request and response types must be protobuf
+	// messages.
+	ProtoRpcCodec RpcCodec = protoRpcCodec{}
+)
+
+type jsonRpcCodec struct{}
+
+func (jsonRpcCodec) Unmarshal(payload string, v interface{}) error {
+	return json.Unmarshal([]byte(payload), v)
+}
+
+func (jsonRpcCodec) Marshal(v interface{}) (string, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+type protoRpcCodec struct{}
+
+// protoMessage returns the protobuf message v is or points to.
+func protoMessage(v interface{}) (proto.Message, error) {
+	if m, ok := v.(proto.Message); ok {
+		return m, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if m, ok := rv.Elem().Interface().(proto.Message); ok {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%T is not a protobuf message", v)
+}
+
+func (protoRpcCodec) Unmarshal(payload string, v interface{}) error {
+	m, err := protoMessage(v)
+	if err != nil {
+		return err
+	}
+	bytes, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(bytes, m)
+}
+
+func (protoRpcCodec) Marshal(v interface{}) (string, error) {
+	m, err := protoMessage(v)
+	if err != nil {
+		return "", err
+	}
+	bytes, err := proto.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bytes), nil
+}
+
+// RpcValidator is implemented by RPC request types that validate their contents.
+type RpcValidator interface {
+	Validate() error
+}
+
+/*
+RegisterRpcTyped registers an RPC function that receives a decoded request and returns a response to encode, using
+the codec to convert them to and from payloads. A nil codec uses JSONRpcCodec. The function is registered through
+Initializer.RegisterRpc, so it works with any server.
+
+An empty payload is passed to the function as an empty request. A payload that fails to decode is rejected with an
+INVALID_ARGUMENT error, as is a request that implements RpcValidator and fails validation, unless the validation
+error is already an *Error. Request types that are pointers are allocated before decoding.
+
+	err := runtime.RegisterRpcTyped(initializer, "claim_reward", nil, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, req *ClaimRequest) (*ClaimResponse, error) {
+		return &ClaimResponse{Coins: 10}, nil
+	})
+*/
+func RegisterRpcTyped[Req, Resp any](initializer Initializer, id string, codec RpcCodec, fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, req Req) (Resp, error)) error {
+	if codec == nil {
+		codec = JSONRpcCodec
+	}
+	return initializer.RegisterRpc(id, func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, payload string) (string, error) {
+		var req Req
+		var target interface{} = &req
+		if t := reflect.TypeFor[Req](); t.Kind() == reflect.Pointer {
+			// Decode into an allocated value so codecs that need the concrete message type can use it.
+			req = reflect.New(t.Elem()).Interface().(Req)
+			target = req
+		}
+		if payload != "" {
+			if err := codec.Unmarshal(payload, target); err != nil {
+				return "", NewError(fmt.Sprintf("error decoding payload: %v", err.Error()), 3)
+			}
+		}
+		if err := validateRpcRequest(req); err != nil {
+			return "", err
+		}
+
+		resp, err := fn(ctx, logger, db, nk, req)
+		if err != nil {
+			return "", err
+		}
+		out, err := codec.Marshal(resp)
+		if err != nil {
+			return "", NewError(fmt.Sprintf("error encoding response: %v", err.Error()), 13)
+		}
+		return out, nil
+	})
+}
+
+// validateRpcRequest calls the Validate method of the request, or of a pointer to it, if it has one.
+func validateRpcRequest[Req any](req Req) error {
+	validator, ok := any(req).(RpcValidator)
+	if !ok {
+		if validator, ok = any(&req).(RpcValidator); !ok {
+			return nil
+		}
+	}
+	if err := validator.Validate(); err != nil {
+		var runtimeErr *Error
+		if errors.As(err, &runtimeErr) {
+			return err
+		}
+		return NewError(err.Error(), 3)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-common/runtime/runtimetest"
+)
+
+type claimRequest struct {
+	Reward string `json:"reward"`
+	Amount int    `json:"amount"`
+}
+
+func (r *claimRequest) Validate() error {
+	if r.Amount < 0 {
+		return errors.New("amount must not be negative")
+	}
+	return nil
+}
+
+type claimResponse struct {
+	Coins int `json:"coins"`
+}
+
+func expectErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var runtimeErr *runtime.Error
+	if !errors.As(err, &runtimeErr) || runtimeErr.Code != code {
+		t.Fatalf("expected error with code %v, got %v", code, err)
+	}
+}
+
+func TestRegisterRpcTypedJSON(t *testing.T) {
+	ctx := context.Background()
+	initializer := runtimetest.NewInitializer(runtimetest.NewNakamaModule())
+	if err := runtime.RegisterRpcTyped(initializer, "claim", nil, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, req claimRequest) (*claimResponse, error) {
+		return &claimResponse{Coins: req.Amount * 2}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := initializer.Rpc(ctx, nil, "claim", `{"reward":"daily","amount":5}`); err != nil || result != `{"coins":10}` {
+		t.Fatalf("unexpected result %q, %v", result, err)
+	}
+	if result, err := initializer.Rpc(ctx, nil, "claim", ""); err != nil || result != `{"coins":0}` {
+		t.Fatalf("expected empty payload to decode as an empty request, got %q, %v", result, err)
+	}
+	_, err := initializer.Rpc(ctx, nil, "claim", `{"amount":"five"}`)
+	expectErrorCode(t, err, 3)
+	_, err = initializer.Rpc(ctx, nil, "claim", `{"amount":-1}`)
+	expectErrorCode(t, err, 3)
+}
+
+func TestRegisterRpcTypedProto(t *testing.T) {
+	ctx := context.Background()
+	initializer := runtimetest.NewInitializer(runtimetest.NewNakamaModule())
+	if err := runtime.RegisterRpcTyped(initializer, "echo", runtime.ProtoRpcCodec, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, req *api.AccountCustom) (*api.AccountCustom, error) {
+		return &api.AccountCustom{Id: req.Id + "!"}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := runtime.ProtoRpcCodec.Marshal(&api.AccountCustom{Id: "custom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := initializer.Rpc(ctx, nil, "echo", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &api.AccountCustom{}
+	if err := runtime.ProtoRpcCodec.Unmarshal(result, out); err != nil || out.Id != "custom!" {
+		t.Fatalf("unexpected response %v, %v", out, err)
+	}
+	_, err = initializer.Rpc(ctx, nil, "echo", "not base64!")
+	expectErrorCode(t, err, 3)
+}
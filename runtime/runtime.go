@@ -160,6 +160,14 @@ const (
 
 	// Trace identifier serves to distinguish requests for debugging purposes.
 	RUNTIME_CTX_TRACE_ID = "trace_id"
+
+	// The matchmaker entry of a presence joining a match to fill a backfill slot. Only applicable to MatchJoinAttempt and
+	// MatchJoin in server authoritative multiplayer.
+	RUNTIME_CTX_MATCHMAKER_ENTRY = "matchmaker_entry"
+
+	// The region the matched users share under the matchmaker latency threshold, as chosen by MatchmakerRegion. Only
+	// applicable to matchmaker matched functions, when the matched users gave latencies.
+	RUNTIME_CTX_MATCHMAKER_REGION = "matchmaker_region"
 )
 
 var (
@@ -191,6 +199,7 @@ var (
 	ErrMatchmakerNotAvailable     = errors.New("matchmaker not available")
 	ErrMatchmakerTooManyTickets   = errors.New("matchmaker too many tickets")
 	ErrMatchmakerTicketNotFound   = errors.New("matchmaker ticket not found")
+	ErrMatchmakerStagesInvalid    = errors.New("matchmaker query stages invalid, wait times must be positive and increasing")
 
 	ErrPartyClosed                   = errors.New("party closed")
 	ErrPartyFull                     = errors.New("party full")
@@ -221,14 +230,27 @@ var (
 
 	ErrWalletLedgerInvalidCursor = errors.New("wallet ledger cursor invalid")
 
-	ErrCannotEncodeParams    = errors.New("error creating match: cannot encode params")
-	ErrCannotDecodeParams    = errors.New("error creating match: cannot decode params")
-	ErrMatchIdInvalid        = errors.New("match id invalid")
-	ErrMatchNotFound         = errors.New("match not found")
-	ErrMatchBusy             = errors.New("match busy")
-	ErrMatchStateFailed      = errors.New("match did not return state")
-	ErrMatchLabelTooLong     = errors.New("match label too long, must be 0-2048 bytes")
-	ErrDeferredBroadcastFull = errors.New("too many deferred message broadcasts per tick")
+	ErrCannotEncodeParams        = errors.New("error creating match: cannot encode params")
+	ErrCannotDecodeParams        = errors.New("error creating match: cannot decode params")
+	ErrMatchIdInvalid            = errors.New("match id invalid")
+	ErrMatchNotFound             = errors.New("match not found")
+	ErrMatchBusy                 = errors.New("match busy")
+	ErrMatchStateFailed          = errors.New("match did not return state")
+	ErrMatchLabelTooLong         = errors.New("match label too long, must be 0-2048 bytes")
+	ErrMatchTickRateInvalid      = errors.New("match tick rate must be between 1 and 60")
+	ErrDeferredBroadcastFull     = errors.New("too many deferred message broadcasts per tick")
+	ErrMatchSnapshotUnsupported  = errors.New("match does not support snapshots")
+	ErrMatchTimerUnsupported     = errors.New("match does not support timers")
+	ErrMatchTimerInvalid         = errors.New("match timer invalid, key must be set and ticks must be at least 1")
+	ErrMatchReconnectUnsupported = errors.New("match does not support reconnection")
+	ErrMatchEnded                = errors.New("match already ended")
+	ErrMatchResultInvalid        = errors.New("match result invalid, presence results must have a user ID")
+	ErrMatchBackfillInvalid      = errors.New("match backfill slots must not be negative")
+
+	ErrRatingRulesetNotFound = errors.New("rating ruleset not found")
+	ErrRatingUpdateInvalid   = errors.New("rating update invalid, expects at least two teams of players with a rank for each")
+
+	ErrQueryNumberInvalid = errors.New("query number invalid, must be finite")
 
 	ErrSatoriConfigurationInvalid = errors.New("satori configuration is invalid")
 )
@@ -263,6 +285,13 @@ For more information, please have a look at the following:
 type Error struct {
 	Message string
 	Code    int
+	// Reason is an optional machine-readable identifier for the error, such as "GROUP_FULL".
+	Reason string
+	// Details are optional key-value pairs sent to the client with the error. Socket clients receive them in the
+	// rtapi.Error context, and HTTP/gRPC clients receive them in the gRPC status details.
+	Details map[string]string
+	// Cause is the optional underlying error, returned by Unwrap. It is not sent to the client.
+	Cause error
 }
 
 // Error returns the encapsulated error message.
@@ -350,7 +379,9 @@ type Initializer interface {
 	*/
 	RegisterAfterRt(id string, fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, out, in *rtapi.Envelope) error) error
 
-	// RegisterMatchmakerMatched
+	// RegisterMatchmakerMatched registers a function called with the entries of each match the matchmaker forms. If the
+	// matched users gave latencies, the region they share is in the context under RUNTIME_CTX_MATCHMAKER_REGION, and can
+	// be passed on to FleetManager.Create with MatchmakerFleetLatencies.
 	RegisterMatchmakerMatched(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, entries []MatchmakerEntry) (string, error)) error
 
 	// RegisterMatchmakerOverride
@@ -359,9 +390,27 @@ type Initializer interface {
 	// RegisterMatchmakerProcessor
 	RegisterMatchmakerProcessor(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, entries []MatchmakerEntry) (matches [][]MatchmakerEntry)) error
 
+	// RegisterMatchmakerScorer registers a function the built-in matchmaker calls to score candidate groupings of entries
+	// whose queries match each other, forming matches from the highest scoring groupings first. Query filtering, party
+	// handling and ticket lifecycle are unchanged. It is called for many groupings on each matchmaker interval, so it
+	// should be fast and should not call the server.
+	RegisterMatchmakerScorer(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, entries []MatchmakerEntry) float64) error
+
 	// RegisterMatch
 	RegisterMatch(name string, fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule) (Match, error)) error
 
+	// RegisterMatchEnd registers a function called with the result of each match ended through MatchDispatcher.MatchEnd,
+	// after the match has stopped.
+	RegisterMatchEnd(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, result *MatchResult) error) error
+
+	// RegisterMatchTickOverrun registers a function called outside the match loop when a tick of an authoritative match
+	// takes longer than the interval between ticks, with the stats of the match and the duration of the tick.
+	RegisterMatchTickOverrun(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, stats *MatchStats, duration time.Duration)) error
+
+	// RegisterRatingRuleset registers a pool of ratings that RatingGet, RatingsUpdate and RatingLedgerList can be called
+	// with. Ratings are updated with the Glicko-2 system, as by UpdateRatings, after being decayed by DecayRating.
+	RegisterRatingRuleset(ruleset *RatingRuleset) error
+
 	// RegisterTournamentEnd
 	RegisterTournamentEnd(fn func(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tournament *api.Tournament, end, reset int64) error) error
 
@@ -925,27 +974,220 @@ type Presence interface {
 	GetNodeId() string
 }
 
+// PresenceSpectator is optionally implemented by a Presence to report whether it joined a match as a spectator.
+// Presences that do not implement it are not spectators.
+type PresenceSpectator interface {
+	GetSpectator() bool
+}
+
 type MatchmakerEntry interface {
 	GetPresence() Presence
 	GetTicket() string
 	GetProperties() map[string]interface{}
 	GetPartyId() string
 	GetCreateTime() int64
+	// GetStage returns the query stage the ticket was matched at, 0 for the query it was added with and i for
+	// query stage i-1.
+	GetStage() int
+	// GetLatencies returns the latency in milliseconds from the user to each region, keyed by region, or nil if the
+	// ticket was added without latencies.
+	GetLatencies() map[string]float32
+}
+
+// MatchmakerQueryStage replaces the query of a matchmaker ticket once the ticket has waited After since it was added,
+// so that tickets match more loosely the longer they wait without losing their place in the matchmaker.
+type MatchmakerQueryStage struct {
+	Query string
+	After time.Duration
+}
+
+// MatchmakerTicket is a matchmaker ticket held by a session, or by a party if PartyID is set. MemberNumericProperties
+// holds the numeric properties set for each member of a party, keyed by user ID, such as their ratings, which the
+// matchmaker entry of the member has in addition to NumericProperties. Stage is the query stage the ticket is at, as
+// returned by StageAt when the ticket was listed. ExpiryTime is 0 for tickets without a TTL.
+type MatchmakerTicket struct {
+	Ticket                  string
+	SessionID               string
+	PartyID                 string
+	Query                   string
+	MinCount                int
+	MaxCount                int
+	CountMultiple           int
+	StringProperties        map[string]string
+	NumericProperties       map[string]float64
+	MemberNumericProperties map[string]map[string]float64
+	Latencies               map[string]float32
+	Stages                  []MatchmakerQueryStage
+	Stage                   int
+	CreateTime              int64
+	ExpiryTime              int64
+}
+
+// StageAt returns the query stage of the ticket at the given time and the query it matches with, which is Query at
+// stage 0 and the query of Stages[stage-1] after.
+func (t *MatchmakerTicket) StageAt(now time.Time) (int, string) {
+	waited := now.Sub(time.Unix(t.CreateTime, 0))
+	stage := 0
+	for stage < len(t.Stages) && t.Stages[stage].After <= waited {
+		stage++
+	}
+	if stage == 0 {
+		return 0, t.Query
+	}
+	return stage, t.Stages[stage-1].Query
 }
 
+// MatchData is a message delivered to MatchLoop. Messages sent through NakamaModule.MatchSend come from the server
+// rather than a client, and have no user or session ID.
 type MatchData interface {
 	Presence
 	GetOpCode() int64
 	GetData() []byte
 	GetReliable() bool
 	GetReceiveTime() int64
+	// GetSequence returns the sequence number the client gave the message, or 0 if it did not set one. Once the
+	// message has been delivered to MatchLoop, the server sends its sequence number back to the client as the ack on
+	// the match data the client receives, along with the tick it was sent on.
+	GetSequence() int64
+	// GetAck returns the last match tick the client had processed when it sent the message, or 0 if it did not set one.
+	GetAck() int64
+}
+
+// MatchMessage is a message sent by BroadcastMessages and BroadcastMessagesDeferred. As with BroadcastMessage, a nil
+// or empty presence list sends the message to every presence in the match, and Sender is optional.
+type MatchMessage struct {
+	OpCode    int64
+	Data      []byte
+	Presences []Presence
+	Sender    Presence
+	Reliable  bool
+}
+
+// MatchOutcome is the outcome of a match for a single user.
+type MatchOutcome int
+
+const (
+	MatchOutcomeNone MatchOutcome = iota
+	MatchOutcomeWin
+	MatchOutcomeLoss
+	MatchOutcomeDraw
+	MatchOutcomeAbandon
+)
+
+// String returns the name of the outcome, such as "win".
+func (o MatchOutcome) String() string {
+	switch o {
+	case MatchOutcomeWin:
+		return "win"
+	case MatchOutcomeLoss:
+		return "loss"
+	case MatchOutcomeDraw:
+		return "draw"
+	case MatchOutcomeAbandon:
+		return "abandon"
+	default:
+		return "none"
+	}
+}
+
+// MatchPresenceResult is the result of a match for a single user.
+type MatchPresenceResult struct {
+	UserID   string
+	Username string
+	Outcome  MatchOutcome
+	Score    int64
+	Metadata map[string]interface{}
+}
+
+// MatchListOptions filters the matches listed by MatchListWithOptions. The fields other than MinSpectators and
+// MaxSpectators are the arguments of MatchList, and nil bounds are not checked.
+type MatchListOptions struct {
+	Limit         int
+	Authoritative bool
+	Label         string
+	MinSize       *int
+	MaxSize       *int
+	Query         string
+	MinSpectators *int
+	MaxSpectators *int
+}
+
+// MatchResult is the result of an authoritative match, recorded through MatchDispatcher.MatchEnd. MatchID, Tick and
+// EndTime, in seconds since the Unix epoch, are set by the server when the result is recorded.
+type MatchResult struct {
+	MatchID   string
+	Tick      int64
+	EndTime   int64
+	Presences []*MatchPresenceResult
+	Metadata  map[string]interface{}
+}
+
+// MatchStats holds the runtime metrics of an authoritative match, or the totals across the running matches of a match
+// module, as returned by MatchModuleStats with no MatchID. Tick durations measure the time spent in the match handler
+// on each tick, and a tick overruns when it takes longer than the interval between ticks at the match tick rate.
+// BroadcastBytes counts the data sent to each recipient of a broadcast.
+type MatchStats struct {
+	MatchID          string
+	Module           string
+	Matches          int
+	Ticks            int64
+	TickDurationAvg  time.Duration
+	TickDurationMax  time.Duration
+	TickOverruns     int64
+	MessagesReceived int64
+	MessagesSent     int64
+	BroadcastBytes   int64
+	Presences        int
+	Spectators       int
 }
 
 type MatchDispatcher interface {
 	BroadcastMessage(opCode int64, data []byte, presences []Presence, sender Presence, reliable bool) error
 	BroadcastMessageDeferred(opCode int64, data []byte, presences []Presence, sender Presence, reliable bool) error
+	// BroadcastMessages sends a batch of messages, each to its own presences, coalescing the messages to each
+	// presence into as few socket writes as possible.
+	BroadcastMessages(messages []*MatchMessage) error
+	// BroadcastMessagesDeferred queues a batch of messages to send at the end of the current tick. Each message
+	// counts towards the deferred broadcast limit per tick, and if the batch does not fit none of it is queued and
+	// ErrDeferredBroadcastFull is returned.
+	BroadcastMessagesDeferred(messages []*MatchMessage) error
+	// BroadcastSpectators sends a message to the spectators in the match, after the spectator delay if one is set.
+	// Spectators receive broadcasts sent to them explicitly, but not those sent with a nil or empty presence list, which
+	// only go to the presences in the match that are not spectators.
+	BroadcastSpectators(opCode int64, data []byte, sender Presence, reliable bool) error
+	// SetSpectatorDelay sets the number of ticks messages sent through BroadcastSpectators are held for before they
+	// are delivered. A delay of 0, the default, delivers them immediately, and a negative delay is rejected with an
+	// InvalidArgument error.
+	SetSpectatorDelay(ticks int64) error
 	MatchKick(presences []Presence) error
 	MatchLabelUpdate(label string) error
+	// SetTickRate changes the match tick rate from the next tick, and the tick rate in RUNTIME_CTX_MATCH_TICK_RATE for
+	// the callbacks after it. The rate must be between 1 and 60, as for MatchInit, or ErrMatchTickRateInvalid is
+	// returned.
+	SetTickRate(rate int) error
+	// ScheduleAfter schedules a timer that fires the given number of ticks after the current tick, replacing any timer
+	// already scheduled with the same key. The match must support MatchFeatureTimer, or ErrMatchTimerUnsupported is
+	// returned.
+	ScheduleAfter(ticks int64, key string, data []byte) error
+	// ScheduleAfterDuration schedules a timer as ScheduleAfter does, after the duration rounded up to a whole number of
+	// ticks at the current tick rate. A later change of the tick rate does not move the timer.
+	ScheduleAfterDuration(d time.Duration, key string, data []byte) error
+	// CancelScheduled cancels the timer scheduled with the key, and returns false if there was none.
+	CancelScheduled(key string) bool
+	// SetReconnectWindow sets how long the slot of a presence that leaves because it disconnected is reserved for its
+	// user to rejoin. A window of 0, the default, disables reservations. The match must support MatchFeatureReconnect,
+	// or ErrMatchReconnectUnsupported is returned.
+	SetReconnectWindow(window time.Duration) error
+	// MatchEnd records the result of the match and ends it once the current callback returns, without calling
+	// MatchTerminate. The result is stored for MatchResultsList and passed to the function registered with
+	// RegisterMatchEnd, which runs outside the match loop. ErrMatchResultInvalid is returned if a presence result has
+	// no user ID, and ErrMatchEnded if the match has already ended.
+	MatchEnd(result *MatchResult) error
+	// SetMatchmakerBackfill opens slots in the match for the matchmaker to fill from queued tickets whose properties
+	// match the query, and whose own queries match the properties given here, replacing any slots opened before.
+	// Players join through MatchJoinAttempt as usual, with their MatchmakerEntry in the context under
+	// RUNTIME_CTX_MATCHMAKER_ENTRY, and each accepted join fills a slot. Setting 0 slots stops the backfill.
+	SetMatchmakerBackfill(slots int, query string, stringProperties map[string]string, numericProperties map[string]float64) error
 }
 
 type Match interface {
@@ -958,6 +1200,46 @@ type Match interface {
 	MatchSignal(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, data string) (interface{}, string)
 }
 
+/*
+MatchTimer is optionally implemented by a Match to receive the timers it schedules through MatchDispatcher.ScheduleAfter.
+Timers due on a tick fire before MatchLoop is called for it, one call per timer, in the order of the ticks they were
+due on and then the order they were scheduled in. Returning a nil state ends the match, and MatchLoop is not called.
+
+A match that only acts on timers does not need to do any work in MatchLoop for the ticks between them.
+*/
+type MatchTimer interface {
+	MatchTimer(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, key string, data []byte) interface{}
+}
+
+/*
+MatchReconnector is optionally implemented by a Match to hold the slots of presences that disconnect, for the window set
+through MatchDispatcher.SetReconnectWindow. The match receives the MatchLeave of a disconnected presence as usual, with
+PresenceReasonDisconnect as its reason, and its slot is then reserved for the same user ID.
+
+If the user joins again within the window, the presence is admitted without calling MatchJoinAttempt and MatchRejoin is
+called instead of MatchJoin. Otherwise MatchReservationExpired is called with the presence that left once the window
+has passed. Returning a nil state from either callback ends the match.
+*/
+type MatchReconnector interface {
+	MatchRejoin(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presence Presence) interface{}
+	MatchReservationExpired(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, dispatcher MatchDispatcher, tick int64, state interface{}, presence Presence) interface{}
+}
+
+/*
+MatchSnapshotter is optionally implemented by a Match to let the server checkpoint and resume it. When a node drains,
+the server snapshots the matches on it that support MatchFeatureSnapshot instead of terminating them, and restores each
+one on another node under the same match ID, tick, tick rate and label, with its presences reconnected. The server
+may also snapshot matches periodically, so that they can be restored after the node running them fails.
+
+MatchSnapshot encodes the state and must not modify it. MatchRestore decodes the state to resume the match with, and
+is called instead of MatchInit. Returning an error or a nil state from MatchRestore fails the restore, and the match
+ends.
+*/
+type MatchSnapshotter interface {
+	MatchSnapshot(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, state interface{}) ([]byte, error)
+	MatchRestore(ctx context.Context, logger Logger, db *sql.DB, nk NakamaModule, tick int64, data []byte) (interface{}, error)
+}
+
 type AccountUpdate struct {
 	UserID      string
 	Username    string
@@ -1132,7 +1414,12 @@ type NakamaModule interface {
 	MatchCreate(ctx context.Context, module string, params map[string]interface{}) (string, error)
 	MatchGet(ctx context.Context, id string) (*api.Match, error)
 	MatchList(ctx context.Context, limit int, authoritative bool, label string, minSize, maxSize *int, query string) ([]*api.Match, error)
+	MatchListWithOptions(ctx context.Context, options *MatchListOptions) ([]*api.Match, error)
 	MatchSignal(ctx context.Context, id string, data string) (string, error)
+	MatchSend(ctx context.Context, id string, opCode int64, data []byte) error
+	MatchStats(ctx context.Context, id string) (*MatchStats, error)
+	MatchModuleStats(ctx context.Context) ([]*MatchStats, error)
+	MatchResultsList(ctx context.Context, matchID, userID string, limit int, cursor string) ([]*MatchResult, string, error)
 
 	NotificationSend(ctx context.Context, userID, subject string, content map[string]interface{}, code int, sender string, persistent bool) error
 	NotificationsList(ctx context.Context, userID string, limit int, cursor string) ([]*api.Notification, string, error)
@@ -1148,6 +1435,10 @@ type NakamaModule interface {
 	WalletLedgerUpdate(ctx context.Context, itemID string, metadata map[string]interface{}) (WalletLedgerItem, error)
 	WalletLedgerList(ctx context.Context, userID string, limit int, cursor string) ([]WalletLedgerItem, string, error)
 
+	RatingGet(ctx context.Context, ruleset, userID string) (*Rating, error)
+	RatingsUpdate(ctx context.Context, ruleset string, teams [][]string, ranks []int, metadata map[string]interface{}) ([][]*Rating, error)
+	RatingLedgerList(ctx context.Context, ruleset, userID string, limit int, cursor string) ([]*RatingLedgerItem, string, error)
+
 	StorageList(ctx context.Context, callerID, userID, collection string, limit int, cursor string) ([]*api.StorageObject, string, error)
 	StorageRead(ctx context.Context, reads []*StorageRead) ([]*api.StorageObject, error)
 	StorageWrite(ctx context.Context, writes []*StorageWrite) ([]*api.StorageObjectAck, error)
@@ -1232,6 +1523,11 @@ type NakamaModule interface {
 	ChannelMessageRemove(ctx context.Context, channelId, messageId string, senderId, senderUsername string, persist bool) (*rtapi.ChannelMessageAck, error)
 	ChannelMessagesList(ctx context.Context, channelId string, limit int, forward bool, cursor string) (messages []*api.ChannelMessage, nextCursor string, prevCursor string, err error)
 
+	MatchmakerAdd(ctx context.Context, sessionID, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64, stages []MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) (string, error)
+	MatchmakerPartyAdd(ctx context.Context, partyID, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64, stages []MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) (string, error)
+	MatchmakerRemove(ctx context.Context, ticket string) error
+	MatchmakerTicketList(ctx context.Context, sessionID, partyID string) ([]*MatchmakerTicket, error)
+
 	PartyList(ctx context.Context, limit int, open *bool, showHidden bool, query, cursor string) ([]*api.Party, string, error)
 
 	StatusFollow(sessionID string, userIDs []string) error
@@ -1330,7 +1626,8 @@ type FleetManager interface {
 	// creation process was either successful or failed.
 	// If a list of userIds is optionally provided, the new instance (on successful creation) will reserve slots
 	// for the respective clients to connect, and the callback will contain the required []*SessionInfo.
-	// Latencies is optional and its support depends on the Fleet Manager provider.
+	// Latencies is optional and its support depends on the Fleet Manager provider. When called from a matchmaker matched
+	// function, the region chosen by the matchmaker is in the context under RUNTIME_CTX_MATCHMAKER_REGION.
 	Create(ctx context.Context, maxPlayers int, userIds []string, latencies []FleetUserLatencies, metadata map[string]any, callback FmCreateCallbackFn) (err error)
 
 	// Join reserves a number of player slots in the target instance. These slots are reserved for a minute, after which,
@@ -0,0 +1,391 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// Rpc invokes the RPC function registered with the given ID. It returns a NOT_FOUND error if there is none.
+func (i *Initializer) Rpc(ctx context.Context, session *Session, id, payload string) (string, error) {
+	i.mu.Lock()
+	fn := i.rpcs[strings.ToLower(id)]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return "", runtime.NewError("RPC function not found", 5)
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeRpc, session), i.Logger, i.DB, i.NK, payload)
+}
+
+// emptyRequest is the result of Before for accepted requests to APIs that take no input.
+var emptyRequest = struct{}{}
+
+/*
+Before invokes the before hook registered for the named API, such as "AuthenticateCustom", with the request in. It
+returns the request as changed by the hook, or in unchanged if no hook is registered. A nil result with a nil error
+means the hook rejected the request. Pass a nil request for APIs that take no input, such as "GetAccount". For these
+the result is an empty struct{} when the request is accepted, and a hook can only reject the request with an error.
+
+The request must have the type the hook was registered with. BeforeHook is a typed form of this function.
+*/
+func (i *Initializer) Before(ctx context.Context, session *Session, name string, in any) (any, error) {
+	i.mu.Lock()
+	fn := i.before[strings.ToLower(name)]
+	i.mu.Unlock()
+
+	if fn == nil {
+		if in == nil {
+			return emptyRequest, nil
+		}
+		return in, nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeBefore, session), in)
+}
+
+// BeforeHook invokes the before hook registered for the named API and returns its result as the request type.
+func BeforeHook[T any](ctx context.Context, i *Initializer, session *Session, name string, in T) (T, error) {
+	result, err := i.Before(ctx, session, name, in)
+	if err != nil || result == nil {
+		var zero T
+		return zero, err
+	}
+	return hookArg[T](name, result)
+}
+
+// After invokes the after hook registered for the named API with the response out and the request in. Either may
+// be nil for APIs that only pass one of them to the hook. It does nothing if no hook is registered.
+func (i *Initializer) After(ctx context.Context, session *Session, name string, out, in any) error {
+	i.mu.Lock()
+	fn := i.after[strings.ToLower(name)]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeAfter, session), out, in)
+}
+
+// envelopeName returns the name realtime hooks are registered under for the message in the envelope.
+func envelopeName(envelope *rtapi.Envelope) string {
+	return strings.ToLower(strings.TrimPrefix(fmt.Sprintf("%T", envelope.GetMessage()), "*rtapi.Envelope_"))
+}
+
+// BeforeRt invokes the before hook registered for the realtime message in the envelope. It returns the envelope
+// unchanged if no hook is registered.
+func (i *Initializer) BeforeRt(ctx context.Context, session *Session, in *rtapi.Envelope) (*rtapi.Envelope, error) {
+	i.mu.Lock()
+	fn := i.beforeRt[envelopeName(in)]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return in, nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeBefore, session), i.Logger, i.DB, i.NK, in)
+}
+
+// AfterRt invokes the after hook registered for the realtime message in the request envelope in.
+func (i *Initializer) AfterRt(ctx context.Context, session *Session, out, in *rtapi.Envelope) error {
+	i.mu.Lock()
+	fn := i.afterRt[envelopeName(in)]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeAfter, session), i.Logger, i.DB, i.NK, out, in)
+}
+
+// Match creates a new instance of the match handler registered with the given name. It returns ErrMatchNotFound if
+// there is none.
+func (i *Initializer) Match(ctx context.Context, name string) (runtime.Match, error) {
+	i.mu.Lock()
+	fn := i.matches[name]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil, runtime.ErrMatchNotFound
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeMatchCreate, nil), i.Logger, i.DB, i.NK)
+}
+
+// MatchEnd invokes the registered match end function with the result of a match, if any. MatchHarness.Result returns
+// the result a match recorded through its dispatcher.
+func (i *Initializer) MatchEnd(ctx context.Context, result *runtime.MatchResult) error {
+	i.mu.Lock()
+	fn := i.matchEnd
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeMatchEnd, nil), i.Logger, i.DB, i.NK, result)
+}
+
+// MatchTickOverrun invokes the registered match tick overrun function, if any. Assign it to
+// MatchHarness.OnTickOverrun to invoke it for the overruns of a match.
+func (i *Initializer) MatchTickOverrun(ctx context.Context, stats *runtime.MatchStats, duration time.Duration) {
+	i.mu.Lock()
+	fn := i.matchTickOverrun
+	i.mu.Unlock()
+
+	if fn != nil {
+		fn(i.context(ctx, runtime.ExecutionModeMatchTickOverrun, nil), i.Logger, i.DB, i.NK, stats, duration)
+	}
+}
+
+// MatchmakerMatched invokes the registered matchmaker matched function, with the region of the entries chosen by
+// runtime.MatchmakerRegion in the context. It returns an empty match ID if there is none, in which case the server
+// would have the matched users join a relayed match, and a FAILED_PRECONDITION error if the entries share no region,
+// as the server would not have matched them together.
+func (i *Initializer) MatchmakerMatched(ctx context.Context, entries []runtime.MatchmakerEntry) (string, error) {
+	i.mu.Lock()
+	fn := i.matchmakerMatched
+	i.mu.Unlock()
+
+	region, ok := runtime.MatchmakerRegion(entries, i.MatchmakerMaxLatency)
+	if !ok {
+		return "", runtime.NewError("matched entries share no region under the latency threshold", 9)
+	}
+	if fn == nil {
+		return "", nil
+	}
+	ctx = i.context(ctx, runtime.ExecutionModeMatchmaker, nil)
+	if region != "" {
+		ctx = runtime.NewContext(ctx, &runtime.RuntimeContext{MatchmakerRegion: runtime.ContextValueOf(region)})
+	}
+	return fn(ctx, i.Logger, i.DB, i.NK, entries)
+}
+
+// MatchmakerOverride invokes the registered matchmaker override function. It returns the candidate matches
+// unchanged if there is none.
+func (i *Initializer) MatchmakerOverride(ctx context.Context, candidateMatches [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry {
+	i.mu.Lock()
+	fn := i.matchmakerOverride
+	i.mu.Unlock()
+
+	if fn == nil {
+		return candidateMatches
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeMatchmakerOverride, nil), i.Logger, i.DB, i.NK, candidateMatches)
+}
+
+// MatchmakerProcessor invokes the registered matchmaker processor function. It returns nil if there is none.
+func (i *Initializer) MatchmakerProcessor(ctx context.Context, entries []runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry {
+	i.mu.Lock()
+	fn := i.matchmakerProcessor
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeMatchmakerProcessor, nil), i.Logger, i.DB, i.NK, entries)
+}
+
+// MatchmakerScore invokes the registered matchmaker scorer function. It returns 0 if there is none.
+func (i *Initializer) MatchmakerScore(ctx context.Context, entries []runtime.MatchmakerEntry) float64 {
+	i.mu.Lock()
+	fn := i.matchmakerScorer
+	i.mu.Unlock()
+
+	if fn == nil {
+		return 0
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeMatchmakerScorer, nil), i.Logger, i.DB, i.NK, entries)
+}
+
+// MatchmakerRank returns the candidate groupings ordered by the registered matchmaker scorer function, highest score
+// first, as the built-in matchmaker forms matches from them. Groupings with equal scores, or all groupings if there is
+// no scorer, keep their order.
+func (i *Initializer) MatchmakerRank(ctx context.Context, candidates [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry {
+	scores := make([]float64, len(candidates))
+	order := make([]int, len(candidates))
+	for n, candidate := range candidates {
+		scores[n] = i.MatchmakerScore(ctx, candidate)
+		order[n] = n
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+
+	ranked := make([][]runtime.MatchmakerEntry, 0, len(candidates))
+	for _, n := range order {
+		ranked = append(ranked, candidates[n])
+	}
+	return ranked
+}
+
+// TournamentEnd invokes the registered tournament end function, if any.
+func (i *Initializer) TournamentEnd(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
+	i.mu.Lock()
+	fn := i.tournamentEnd
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeTournamentEnd, nil), i.Logger, i.DB, i.NK, tournament, end, reset)
+}
+
+// TournamentReset invokes the registered tournament reset function, if any.
+func (i *Initializer) TournamentReset(ctx context.Context, tournament *api.Tournament, end, reset int64) error {
+	i.mu.Lock()
+	fn := i.tournamentReset
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeTournamentReset, nil), i.Logger, i.DB, i.NK, tournament, end, reset)
+}
+
+// LeaderboardReset invokes the registered leaderboard reset function, if any.
+func (i *Initializer) LeaderboardReset(ctx context.Context, leaderboard *api.Leaderboard, reset int64) error {
+	i.mu.Lock()
+	fn := i.leaderboardReset
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeLeaderboardReset, nil), i.Logger, i.DB, i.NK, leaderboard, reset)
+}
+
+// PurchaseNotificationApple invokes the registered Apple purchase notification function, if any.
+func (i *Initializer) PurchaseNotificationApple(ctx context.Context, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, payload *runtime.AppleNotificationData) error {
+	i.mu.Lock()
+	fn := i.purchaseNotificationApple
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModePurchaseNotificationApple, nil), i.Logger, i.DB, i.NK, notificationType, purchase, payload)
+}
+
+// SubscriptionNotificationApple invokes the registered Apple subscription notification function, if any.
+func (i *Initializer) SubscriptionNotificationApple(ctx context.Context, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, payload *runtime.AppleNotificationData) error {
+	i.mu.Lock()
+	fn := i.subscriptionNotificationApple
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeSubscriptionNotificationApple, nil), i.Logger, i.DB, i.NK, notificationType, subscription, payload)
+}
+
+// PurchaseNotificationGoogle invokes the registered Google purchase notification function, if any.
+func (i *Initializer) PurchaseNotificationGoogle(ctx context.Context, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, providerPayload *runtime.PurchaseV2GoogleResponse) error {
+	i.mu.Lock()
+	fn := i.purchaseNotificationGoogle
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModePurchaseNotificationGoogle, nil), i.Logger, i.DB, i.NK, notificationType, purchase, providerPayload)
+}
+
+// SubscriptionNotificationGoogle invokes the registered Google subscription notification function, if any.
+func (i *Initializer) SubscriptionNotificationGoogle(ctx context.Context, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, providerPayload *runtime.SubscriptionV2GoogleResponse) error {
+	i.mu.Lock()
+	fn := i.subscriptionNotificationGoogle
+	i.mu.Unlock()
+
+	if fn == nil {
+		return nil
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeSubscriptionNotificationGoogle, nil), i.Logger, i.DB, i.NK, notificationType, subscription, providerPayload)
+}
+
+// Event invokes every registered event function with the event, in the order they were registered.
+func (i *Initializer) Event(ctx context.Context, evt *api.Event) {
+	i.mu.Lock()
+	fns := i.events
+	i.mu.Unlock()
+
+	i.invokeEvent(ctx, nil, fns, evt)
+}
+
+// EventSessionStart invokes every registered session start function with the event.
+func (i *Initializer) EventSessionStart(ctx context.Context, session *Session, evt *api.Event) {
+	i.mu.Lock()
+	fns := i.eventSessionStart
+	i.mu.Unlock()
+
+	i.invokeEvent(ctx, session, fns, evt)
+}
+
+// EventSessionEnd invokes every registered session end function with the event.
+func (i *Initializer) EventSessionEnd(ctx context.Context, session *Session, evt *api.Event) {
+	i.mu.Lock()
+	fns := i.eventSessionEnd
+	i.mu.Unlock()
+
+	i.invokeEvent(ctx, session, fns, evt)
+}
+
+func (i *Initializer) invokeEvent(ctx context.Context, session *Session, fns []eventFunction, evt *api.Event) {
+	ctx = i.context(ctx, runtime.ExecutionModeEvent, session)
+	for _, fn := range fns {
+		fn(ctx, i.Logger, evt)
+	}
+}
+
+// StorageIndexFilter invokes the filter function registered for the storage index. It returns true, indexing the
+// write, if there is none.
+func (i *Initializer) StorageIndexFilter(ctx context.Context, indexName string, write *runtime.StorageWrite) bool {
+	i.mu.Lock()
+	fn := i.storageIndexFilter[indexName]
+	i.mu.Unlock()
+
+	if fn == nil {
+		return true
+	}
+	return fn(i.context(ctx, runtime.ExecutionModeStorageIndexFilter, nil), i.Logger, i.DB, i.NK, write)
+}
+
+// Shutdown invokes the registered shutdown function, if any.
+func (i *Initializer) Shutdown(ctx context.Context) {
+	i.mu.Lock()
+	fn := i.shutdown
+	i.mu.Unlock()
+
+	if fn != nil {
+		fn(i.context(ctx, runtime.ExecutionModeShutdown, nil), i.Logger, i.DB, i.NK)
+	}
+}
+
+// Http returns a handler that routes requests to the functions registered with RegisterHttp. Path patterns and
+// methods are matched as by http.ServeMux.
+func (i *Initializer) Http() http.Handler {
+	return i.httpMux
+}
+
+// ConsoleHttp returns a handler that routes requests to the functions registered with RegisterConsoleHttp.
+func (i *Initializer) ConsoleHttp() http.Handler {
+	return i.consoleHttpMux
+}
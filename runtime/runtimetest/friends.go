@@ -0,0 +1,248 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	friendStateFriend = iota
+	friendStateInviteSent
+	friendStateInviteReceived
+	friendStateBlocked
+)
+
+const (
+	notificationCodeFriendRequest = -2
+	notificationCodeFriendAccept  = -3
+)
+
+type friendEdge struct {
+	state      int
+	position   int64
+	updateTime time.Time
+	metadata   string
+}
+
+func (n *NakamaModule) friendCountLocked(userID string) int {
+	count := 0
+	for _, edge := range n.friends[userID] {
+		if edge.state == friendStateFriend {
+			count++
+		}
+	}
+	return count
+}
+
+// resolveUsersLocked returns the IDs of the given users, ignoring the calling user and any users that do not exist.
+func (n *NakamaModule) resolveUsersLocked(userID string, ids, usernames []string) []string {
+	resolved := make([]string, 0, len(ids)+len(usernames))
+	seen := map[string]bool{userID: true}
+	for _, id := range ids {
+		if _, ok := n.accounts[id]; ok && !seen[id] {
+			seen[id] = true
+			resolved = append(resolved, id)
+		}
+	}
+	for _, username := range usernames {
+		if id, ok := n.usernames[username]; ok && !seen[id] {
+			seen[id] = true
+			resolved = append(resolved, id)
+		}
+	}
+	return resolved
+}
+
+func (n *NakamaModule) setFriendEdgeLocked(userID, friendID string, state int, metadata string) {
+	edges, ok := n.friends[userID]
+	if !ok {
+		edges = make(map[string]*friendEdge)
+		n.friends[userID] = edges
+	}
+	now := n.Now()
+	edge, ok := edges[friendID]
+	if !ok {
+		edge = &friendEdge{position: n.nextSeqLocked(), metadata: "{}"}
+		edges[friendID] = edge
+	}
+	edge.state = state
+	edge.updateTime = now
+	if metadata != "" {
+		edge.metadata = metadata
+	}
+}
+
+func (n *NakamaModule) FriendsAdd(ctx context.Context, userID string, username string, ids []string, usernames []string, metadata map[string]any) error {
+	encoded := ""
+	if metadata != nil {
+		bytes, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+		encoded = string(bytes)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.accounts[userID]; !ok {
+		return errAccountNotFound
+	}
+	now := timestamppb.New(n.Now())
+	notifications := make([]*sentNotification, 0)
+	for _, friendID := range n.resolveUsersLocked(userID, ids, usernames) {
+		if edge, ok := n.friends[friendID][userID]; ok && edge.state == friendStateBlocked {
+			// Users that have blocked the caller silently ignore the request.
+			continue
+		}
+		code, subject := notificationCodeFriendRequest, fmt.Sprintf("%v wants to add you as a friend", username)
+		switch edge := n.friends[userID][friendID]; {
+		case edge == nil, edge.state == friendStateBlocked:
+			n.setFriendEdgeLocked(userID, friendID, friendStateInviteSent, encoded)
+			n.setFriendEdgeLocked(friendID, userID, friendStateInviteReceived, "")
+		case edge.state == friendStateInviteReceived:
+			n.setFriendEdgeLocked(userID, friendID, friendStateFriend, encoded)
+			n.setFriendEdgeLocked(friendID, userID, friendStateFriend, "")
+			code, subject = notificationCodeFriendAccept, fmt.Sprintf("%v accepted your friend request", username)
+		default:
+			continue
+		}
+		content, _ := json.Marshal(map[string]string{"username": username})
+		notifications = append(notifications, &sentNotification{
+			userID: friendID,
+			notification: &api.Notification{
+				Id:         generateID(),
+				Subject:    subject,
+				Content:    string(content),
+				Code:       int32(code),
+				SenderId:   userID,
+				CreateTime: now,
+				Persistent: true,
+			},
+		})
+	}
+	n.deliverLocked(notifications)
+	return nil
+}
+
+func (n *NakamaModule) FriendsDelete(ctx context.Context, userID string, username string, ids []string, usernames []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, friendID := range n.resolveUsersLocked(userID, ids, usernames) {
+		delete(n.friends[userID], friendID)
+		if edge, ok := n.friends[friendID][userID]; ok && edge.state != friendStateBlocked {
+			delete(n.friends[friendID], userID)
+		}
+	}
+	return nil
+}
+
+func (n *NakamaModule) FriendsBlock(ctx context.Context, userID string, username string, ids []string, usernames []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, friendID := range n.resolveUsersLocked(userID, ids, usernames) {
+		n.setFriendEdgeLocked(userID, friendID, friendStateBlocked, "")
+		if edge, ok := n.friends[friendID][userID]; ok && edge.state != friendStateBlocked {
+			delete(n.friends[friendID], userID)
+		}
+	}
+	return nil
+}
+
+func (n *NakamaModule) FriendMetadataUpdate(ctx context.Context, userID string, friendUserId string, metadata map[string]any) error {
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("error encoding metadata: %v", err.Error())
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	edge, ok := n.friends[userID][friendUserId]
+	if !ok {
+		return errors.New("friend not found")
+	}
+	edge.metadata = string(encoded)
+	edge.updateTime = n.Now()
+	return nil
+}
+
+// FriendsList returns the friend edges of a user in the order they were created.
+func (n *NakamaModule) FriendsList(ctx context.Context, userID string, limit int, state *int, cursor string) ([]*api.Friend, string, error) {
+	if state != nil && (*state < friendStateFriend || *state > friendStateBlocked) {
+		return nil, "", errors.New("expects state to be 0, 1, 2 or 3")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	friendIDs := make([]string, 0, len(n.friends[userID]))
+	for friendID, edge := range n.friends[userID] {
+		if state == nil || edge.state == *state {
+			friendIDs = append(friendIDs, friendID)
+		}
+	}
+	edges := n.friends[userID]
+	sort.Slice(friendIDs, func(i, j int) bool {
+		return edges[friendIDs[i]].position < edges[friendIDs[j]].position
+	})
+
+	start, end, next, err := paginate(cursor, limit, len(friendIDs))
+	if err != nil {
+		return nil, "", runtime.ErrFriendInvalidCursor
+	}
+	friends := make([]*api.Friend, 0, end-start)
+	for _, friendID := range friendIDs[start:end] {
+		friends = append(friends, n.exportFriendLocked(userID, friendID))
+	}
+	return friends, next, nil
+}
+
+func (n *NakamaModule) exportFriendLocked(userID, friendID string) *api.Friend {
+	edge := n.friends[userID][friendID]
+	return &api.Friend{
+		User:       proto.Clone(n.accounts[friendID].user).(*api.User),
+		State:      wrapperspb.Int32(int32(edge.state)),
+		UpdateTime: timestamppb.New(edge.updateTime),
+		Metadata:   edge.metadata,
+	}
+}
+
+func (n *NakamaModule) UsersGetFriendStatus(ctx context.Context, userID string, userIDs []string) ([]*api.Friend, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	friends := make([]*api.Friend, 0, len(userIDs))
+	for _, friendID := range userIDs {
+		if _, ok := n.friends[userID][friendID]; ok {
+			friends = append(friends, n.exportFriendLocked(userID, friendID))
+		}
+	}
+	return friends, nil
+}
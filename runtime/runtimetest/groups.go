@@ -0,0 +1,498 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	groupStateSuperadmin = iota
+	groupStateAdmin
+	groupStateMember
+	groupStateJoinRequest
+	groupStateBanned
+)
+
+type groupMember struct {
+	state    int
+	position int64
+}
+
+type group struct {
+	group    *api.Group
+	position int64
+	members  map[string]*groupMember
+}
+
+func (g *group) edgeCount() int {
+	count := 0
+	for _, member := range g.members {
+		if member.state <= groupStateMember {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *group) superadminCount() int {
+	count := 0
+	for _, member := range g.members {
+		if member.state == groupStateSuperadmin {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *group) export() *api.Group {
+	exported := proto.Clone(g.group).(*api.Group)
+	exported.EdgeCount = int32(g.edgeCount())
+	return exported
+}
+
+func (n *NakamaModule) GroupCreate(ctx context.Context, userID, name, creatorID, langTag, description, avatarUrl string, open bool, metadata map[string]interface{}, maxCount int) (*api.Group, error) {
+	if userID == "" {
+		return nil, errors.New("expects user ID to be a valid identifier")
+	}
+	if name == "" {
+		return nil, errors.New("expects group name not be empty")
+	}
+	if creatorID == "" {
+		creatorID = userID
+	}
+	if maxCount == 0 {
+		maxCount = 100
+	}
+	if maxCount < 1 {
+		return nil, errors.New("expects max_count to be >= 1")
+	}
+	encoded := []byte("{}")
+	if metadata != nil {
+		var err error
+		if encoded, err = json.Marshal(metadata); err != nil {
+			return nil, fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.accounts[creatorID]; !ok {
+		return nil, runtime.ErrGroupCreatorInvalid
+	}
+	if _, ok := n.groupNames[name]; ok {
+		return nil, runtime.ErrGroupNameInUse
+	}
+
+	now := timestamppb.New(n.Now())
+	g := &group{
+		group: &api.Group{
+			Id:          generateID(),
+			CreatorId:   creatorID,
+			Name:        name,
+			Description: description,
+			LangTag:     langTag,
+			Metadata:    string(encoded),
+			AvatarUrl:   avatarUrl,
+			Open:        wrapperspb.Bool(open),
+			MaxCount:    int32(maxCount),
+			CreateTime:  now,
+			UpdateTime:  now,
+		},
+		position: n.nextSeqLocked(),
+		members:  make(map[string]*groupMember),
+	}
+	if _, ok := n.accounts[userID]; ok {
+		g.members[userID] = &groupMember{state: groupStateSuperadmin, position: n.nextSeqLocked()}
+	}
+	n.groups[g.group.Id] = g
+	n.groupNames[name] = g.group.Id
+	return g.export(), nil
+}
+
+// GroupUpdate changes the given group fields. Empty strings, nil metadata and a zero max count leave the existing
+// values unchanged. A non-empty userID must belong to an admin of the group.
+func (n *NakamaModule) GroupUpdate(ctx context.Context, id, userID, name, creatorID, langTag, description, avatarUrl string, open bool, metadata map[string]interface{}, maxCount int) error {
+	var encoded []byte
+	if metadata != nil {
+		var err error
+		if encoded, err = json.Marshal(metadata); err != nil {
+			return fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, ok := n.groups[id]
+	if !ok {
+		return runtime.ErrGroupNotFound
+	}
+	if userID != "" {
+		if member, ok := g.members[userID]; !ok || member.state > groupStateAdmin {
+			return runtime.ErrGroupPermissionDenied
+		}
+	}
+	if name != "" && name != g.group.Name {
+		if _, ok := n.groupNames[name]; ok {
+			return runtime.ErrGroupNameInUse
+		}
+		delete(n.groupNames, g.group.Name)
+		n.groupNames[name] = id
+		g.group.Name = name
+	}
+	for dst, src := range map[*string]string{
+		&g.group.CreatorId:   creatorID,
+		&g.group.LangTag:     langTag,
+		&g.group.Description: description,
+		&g.group.AvatarUrl:   avatarUrl,
+	} {
+		if src != "" {
+			*dst = src
+		}
+	}
+	if encoded != nil {
+		g.group.Metadata = string(encoded)
+	}
+	if maxCount > 0 {
+		g.group.MaxCount = int32(maxCount)
+	}
+	g.group.Open = wrapperspb.Bool(open)
+	g.group.UpdateTime = timestamppb.New(n.Now())
+	return nil
+}
+
+func (n *NakamaModule) GroupDelete(ctx context.Context, id string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if g, ok := n.groups[id]; ok {
+		delete(n.groupNames, g.group.Name)
+		delete(n.groups, id)
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupsGetId(ctx context.Context, groupIDs []string) ([]*api.Group, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	groups := make([]*api.Group, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		if g, ok := n.groups[id]; ok {
+			groups = append(groups, g.export())
+		}
+	}
+	return groups, nil
+}
+
+// GroupsList filters groups by name, language, member count and open state. A name ending with "%" matches any
+// group name with that prefix.
+func (n *NakamaModule) GroupsList(ctx context.Context, name, langTag string, members *int, open *bool, limit int, cursor string) ([]*api.Group, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	groups := make([]*group, 0)
+	for _, g := range n.groups {
+		switch {
+		case strings.HasSuffix(name, "%") && !strings.HasPrefix(g.group.Name, strings.TrimSuffix(name, "%")):
+		case name != "" && !strings.HasSuffix(name, "%") && g.group.Name != name:
+		case langTag != "" && g.group.LangTag != langTag:
+		case members != nil && g.edgeCount() > *members:
+		case open != nil && g.group.Open.GetValue() != *open:
+		default:
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].position < groups[j].position })
+
+	start, end, next, err := paginate(cursor, limit, len(groups))
+	if err != nil {
+		return nil, "", err
+	}
+	page := make([]*api.Group, 0, end-start)
+	for _, g := range groups[start:end] {
+		page = append(page, g.export())
+	}
+	return page, next, nil
+}
+
+func (n *NakamaModule) GroupUserJoin(ctx context.Context, groupID, userID, username string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, ok := n.groups[groupID]
+	if !ok {
+		return runtime.ErrGroupNotFound
+	}
+	if _, ok := n.accounts[userID]; !ok {
+		return runtime.ErrGroupUserNotFound
+	}
+	if member, ok := g.members[userID]; ok {
+		if member.state == groupStateBanned {
+			return runtime.ErrGroupPermissionDenied
+		}
+		return nil
+	}
+	if !g.group.Open.GetValue() {
+		g.members[userID] = &groupMember{state: groupStateJoinRequest, position: n.nextSeqLocked()}
+		return nil
+	}
+	if g.edgeCount() >= int(g.group.MaxCount) {
+		return runtime.ErrGroupFull
+	}
+	g.members[userID] = &groupMember{state: groupStateMember, position: n.nextSeqLocked()}
+	return nil
+}
+
+func (n *NakamaModule) GroupUserLeave(ctx context.Context, groupID, userID, username string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, ok := n.groups[groupID]
+	if !ok {
+		return runtime.ErrGroupNotFound
+	}
+	member, ok := g.members[userID]
+	if !ok || member.state == groupStateBanned {
+		return nil
+	}
+	if member.state == groupStateSuperadmin && g.superadminCount() == 1 {
+		return runtime.ErrGroupLastSuperadmin
+	}
+	delete(g.members, userID)
+	return nil
+}
+
+// groupCallerLocked returns the group and the state of the calling user. An empty callerID acts as the server, which
+// has superadmin permissions in every group.
+func (n *NakamaModule) groupCallerLocked(callerID, groupID string) (*group, int, error) {
+	g, ok := n.groups[groupID]
+	if !ok {
+		return nil, 0, runtime.ErrGroupNotFound
+	}
+	if callerID == "" {
+		return g, groupStateSuperadmin, nil
+	}
+	member, ok := g.members[callerID]
+	if !ok || member.state > groupStateAdmin {
+		return nil, 0, runtime.ErrGroupPermissionDenied
+	}
+	return g, member.state, nil
+}
+
+// outranks reports whether a member in the caller state may kick, ban or demote a member in the target state.
+func outranks(callerState, targetState int) bool {
+	return callerState == groupStateSuperadmin || callerState < targetState
+}
+
+func (n *NakamaModule) GroupUsersAdd(ctx context.Context, callerID, groupID string, userIDs []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, _, err := n.groupCallerLocked(callerID, groupID)
+	if err != nil {
+		return err
+	}
+	added := 0
+	for _, userID := range userIDs {
+		if _, ok := n.accounts[userID]; !ok {
+			return runtime.ErrGroupUserNotFound
+		}
+		if member, ok := g.members[userID]; !ok || member.state == groupStateJoinRequest {
+			added++
+		}
+	}
+	if g.edgeCount()+added > int(g.group.MaxCount) {
+		return runtime.ErrGroupFull
+	}
+	for _, userID := range userIDs {
+		switch member, ok := g.members[userID]; {
+		case !ok:
+			g.members[userID] = &groupMember{state: groupStateMember, position: n.nextSeqLocked()}
+		case member.state == groupStateJoinRequest:
+			member.state = groupStateMember
+		}
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupUsersKick(ctx context.Context, callerID, groupID string, userIDs []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, callerState, err := n.groupCallerLocked(callerID, groupID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		member, ok := g.members[userID]
+		if !ok || member.state == groupStateBanned || userID == callerID {
+			continue
+		}
+		if !outranks(callerState, member.state) {
+			return runtime.ErrGroupPermissionDenied
+		}
+		if member.state == groupStateSuperadmin && g.superadminCount() == 1 {
+			return runtime.ErrGroupLastSuperadmin
+		}
+		delete(g.members, userID)
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupUsersBan(ctx context.Context, callerID, groupID string, userIDs []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, callerState, err := n.groupCallerLocked(callerID, groupID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if _, ok := n.accounts[userID]; !ok || userID == callerID {
+			continue
+		}
+		member, ok := g.members[userID]
+		if !ok {
+			g.members[userID] = &groupMember{state: groupStateBanned, position: n.nextSeqLocked()}
+			continue
+		}
+		if !outranks(callerState, member.state) {
+			return runtime.ErrGroupPermissionDenied
+		}
+		if member.state == groupStateSuperadmin && g.superadminCount() == 1 {
+			return runtime.ErrGroupLastSuperadmin
+		}
+		member.state = groupStateBanned
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupUsersPromote(ctx context.Context, callerID, groupID string, userIDs []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, callerState, err := n.groupCallerLocked(callerID, groupID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		member, ok := g.members[userID]
+		if !ok || member.state == groupStateBanned || member.state == groupStateSuperadmin {
+			continue
+		}
+		if member.state-1 < callerState {
+			return runtime.ErrGroupPermissionDenied
+		}
+		if member.state == groupStateJoinRequest && g.edgeCount() >= int(g.group.MaxCount) {
+			return runtime.ErrGroupFull
+		}
+		member.state--
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupUsersDemote(ctx context.Context, callerID, groupID string, userIDs []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, callerState, err := n.groupCallerLocked(callerID, groupID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		member, ok := g.members[userID]
+		if !ok || member.state >= groupStateMember {
+			continue
+		}
+		if !outranks(callerState, member.state) {
+			return runtime.ErrGroupPermissionDenied
+		}
+		if member.state == groupStateSuperadmin && g.superadminCount() == 1 {
+			return runtime.ErrGroupLastSuperadmin
+		}
+		member.state++
+	}
+	return nil
+}
+
+func (n *NakamaModule) GroupUsersList(ctx context.Context, id string, limit int, state *int, cursor string) ([]*api.GroupUserList_GroupUser, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	g, ok := n.groups[id]
+	if !ok {
+		return nil, "", runtime.ErrGroupNotFound
+	}
+	userIDs := make([]string, 0, len(g.members))
+	for userID, member := range g.members {
+		if state == nil || member.state == *state {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return g.members[userIDs[i]].position < g.members[userIDs[j]].position })
+
+	start, end, next, err := paginate(cursor, limit, len(userIDs))
+	if err != nil {
+		return nil, "", runtime.ErrGroupUserInvalidCursor
+	}
+	users := make([]*api.GroupUserList_GroupUser, 0, end-start)
+	for _, userID := range userIDs[start:end] {
+		users = append(users, &api.GroupUserList_GroupUser{
+			User:  proto.Clone(n.accounts[userID].user).(*api.User),
+			State: wrapperspb.Int32(int32(g.members[userID].state)),
+		})
+	}
+	return users, next, nil
+}
+
+func (n *NakamaModule) UserGroupsList(ctx context.Context, userID string, limit int, state *int, cursor string) ([]*api.UserGroupList_UserGroup, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	groups := make([]*group, 0)
+	for _, g := range n.groups {
+		if member, ok := g.members[userID]; ok && (state == nil || member.state == *state) {
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].position < groups[j].position })
+
+	start, end, next, err := paginate(cursor, limit, len(groups))
+	if err != nil {
+		return nil, "", runtime.ErrUserGroupInvalidCursor
+	}
+	userGroups := make([]*api.UserGroupList_UserGroup, 0, end-start)
+	for _, g := range groups[start:end] {
+		userGroups = append(userGroups, &api.UserGroupList_UserGroup{
+			Group: g.export(),
+			State: wrapperspb.Int32(int32(g.members[userID].state)),
+		})
+	}
+	return userGroups, next, nil
+}
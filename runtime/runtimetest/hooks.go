@@ -0,0 +1,765 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// hookArg converts a value passed to Before or After to the type the registered hook expects. A nil value is
+// passed to the hook as the zero value of its type.
+func hookArg[T any](name string, v any) (T, error) {
+	var zero T
+	if v == nil {
+		return zero, nil
+	}
+	t, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("hook %v expects %T, got %T", name, zero, v)
+	}
+	return t, nil
+}
+
+func (i *Initializer) registerBeforeFunction(name string, fn beforeFunction) error {
+	id := strings.ToLower(name)
+	return i.register("before", id, func() bool { return i.before[id] != nil }, func() { i.before[id] = fn })
+}
+
+func (i *Initializer) registerAfterFunction(name string, fn afterFunction) error {
+	id := strings.ToLower(name)
+	return i.register("after", id, func() bool { return i.after[id] != nil }, func() { i.after[id] = fn })
+}
+
+// registerBefore registers a before hook for an API that takes a request of type T. A nil request returned by the
+// hook is returned as an untyped nil, so that Before reports the rejection as a nil result.
+func registerBefore[T comparable](i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in T) (T, error)) error {
+	return i.registerBeforeFunction(name, func(ctx context.Context, in any) (any, error) {
+		v, err := hookArg[T](name, in)
+		if err != nil {
+			return nil, err
+		}
+		out, err := fn(ctx, i.Logger, i.DB, i.NK, v)
+		var zero T
+		if out == zero {
+			return nil, err
+		}
+		return out, err
+	})
+}
+
+// registerBeforeEmpty registers a before hook for an API that takes no input. The hook returns emptyRequest when it
+// accepts the request, so that a nil result still only means a rejection.
+func registerBeforeEmpty(i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return i.registerBeforeFunction(name, func(ctx context.Context, in any) (any, error) {
+		if err := fn(ctx, i.Logger, i.DB, i.NK); err != nil {
+			return nil, err
+		}
+		return emptyRequest, nil
+	})
+}
+
+func registerAfter[Out, In any](i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out Out, in In) error) error {
+	return i.registerAfterFunction(name, func(ctx context.Context, out, in any) error {
+		o, err := hookArg[Out](name, out)
+		if err != nil {
+			return err
+		}
+		v, err := hookArg[In](name, in)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, i.Logger, i.DB, i.NK, o, v)
+	})
+}
+
+func registerAfterIn[In any](i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in In) error) error {
+	return i.registerAfterFunction(name, func(ctx context.Context, out, in any) error {
+		v, err := hookArg[In](name, in)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, i.Logger, i.DB, i.NK, v)
+	})
+}
+
+func registerAfterOut[Out any](i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out Out) error) error {
+	return i.registerAfterFunction(name, func(ctx context.Context, out, in any) error {
+		o, err := hookArg[Out](name, out)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, i.Logger, i.DB, i.NK, o)
+	})
+}
+
+func registerAfterEmpty(i *Initializer, name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return i.registerAfterFunction(name, func(ctx context.Context, out, in any) error {
+		return fn(ctx, i.Logger, i.DB, i.NK)
+	})
+}
+
+func (i *Initializer) RegisterBeforeGetAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return registerBeforeEmpty(i, "GetAccount", fn)
+}
+
+func (i *Initializer) RegisterAfterGetAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error) error {
+	return registerAfterOut(i, "GetAccount", fn)
+}
+
+func (i *Initializer) RegisterBeforeUpdateAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) (*api.UpdateAccountRequest, error)) error {
+	return registerBefore(i, "UpdateAccount", fn)
+}
+
+func (i *Initializer) RegisterAfterUpdateAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateAccountRequest) error) error {
+	return registerAfterIn(i, "UpdateAccount", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return registerBeforeEmpty(i, "DeleteAccount", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteAccount(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return registerAfterEmpty(i, "DeleteAccount", fn)
+}
+
+func (i *Initializer) RegisterBeforeSessionRefresh(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.SessionRefreshRequest) (*api.SessionRefreshRequest, error)) error {
+	return registerBefore(i, "SessionRefresh", fn)
+}
+
+func (i *Initializer) RegisterAfterSessionRefresh(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.SessionRefreshRequest) error) error {
+	return registerAfter(i, "SessionRefresh", fn)
+}
+
+func (i *Initializer) RegisterBeforeSessionLogout(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.SessionLogoutRequest) (*api.SessionLogoutRequest, error)) error {
+	return registerBefore(i, "SessionLogout", fn)
+}
+
+func (i *Initializer) RegisterAfterSessionLogout(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.SessionLogoutRequest) error) error {
+	return registerAfterIn(i, "SessionLogout", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateAppleRequest) (*api.AuthenticateAppleRequest, error)) error {
+	return registerBefore(i, "AuthenticateApple", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateAppleRequest) error) error {
+	return registerAfter(i, "AuthenticateApple", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error)) error {
+	return registerBefore(i, "AuthenticateCustom", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateCustomRequest) error) error {
+	return registerAfter(i, "AuthenticateCustom", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateDeviceRequest) (*api.AuthenticateDeviceRequest, error)) error {
+	return registerBefore(i, "AuthenticateDevice", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateDeviceRequest) error) error {
+	return registerAfter(i, "AuthenticateDevice", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateEmailRequest) (*api.AuthenticateEmailRequest, error)) error {
+	return registerBefore(i, "AuthenticateEmail", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateEmailRequest) error) error {
+	return registerAfter(i, "AuthenticateEmail", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookRequest) (*api.AuthenticateFacebookRequest, error)) error {
+	return registerBefore(i, "AuthenticateFacebook", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookRequest) error) error {
+	return registerAfter(i, "AuthenticateFacebook", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateFacebookInstantGameRequest) (*api.AuthenticateFacebookInstantGameRequest, error)) error {
+	return registerBefore(i, "AuthenticateFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateFacebookInstantGameRequest) error) error {
+	return registerAfter(i, "AuthenticateFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGameCenterRequest) (*api.AuthenticateGameCenterRequest, error)) error {
+	return registerBefore(i, "AuthenticateGameCenter", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGameCenterRequest) error) error {
+	return registerAfter(i, "AuthenticateGameCenter", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateGoogleRequest) (*api.AuthenticateGoogleRequest, error)) error {
+	return registerBefore(i, "AuthenticateGoogle", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateGoogleRequest) error) error {
+	return registerAfter(i, "AuthenticateGoogle", fn)
+}
+
+func (i *Initializer) RegisterBeforeAuthenticateSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateSteamRequest) (*api.AuthenticateSteamRequest, error)) error {
+	return registerBefore(i, "AuthenticateSteam", fn)
+}
+
+func (i *Initializer) RegisterAfterAuthenticateSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Session, in *api.AuthenticateSteamRequest) error) error {
+	return registerAfter(i, "AuthenticateSteam", fn)
+}
+
+func (i *Initializer) RegisterBeforeListChannelMessages(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListChannelMessagesRequest) (*api.ListChannelMessagesRequest, error)) error {
+	return registerBefore(i, "ListChannelMessages", fn)
+}
+
+func (i *Initializer) RegisterAfterListChannelMessages(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ChannelMessageList, in *api.ListChannelMessagesRequest) error) error {
+	return registerAfter(i, "ListChannelMessages", fn)
+}
+
+func (i *Initializer) RegisterBeforeListFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListFriendsRequest) (*api.ListFriendsRequest, error)) error {
+	return registerBefore(i, "ListFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterListFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.FriendList) error) error {
+	return registerAfterOut(i, "ListFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeListFriendsOfFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListFriendsOfFriendsRequest) (*api.ListFriendsOfFriendsRequest, error)) error {
+	return registerBefore(i, "ListFriendsOfFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterListFriendsOfFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.FriendsOfFriendsList) error) error {
+	return registerAfterOut(i, "ListFriendsOfFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeAddFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) (*api.AddFriendsRequest, error)) error {
+	return registerBefore(i, "AddFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterAddFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddFriendsRequest) error) error {
+	return registerAfterIn(i, "AddFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) (*api.DeleteFriendsRequest, error)) error {
+	return registerBefore(i, "DeleteFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteFriendsRequest) error) error {
+	return registerAfterIn(i, "DeleteFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeBlockFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) (*api.BlockFriendsRequest, error)) error {
+	return registerBefore(i, "BlockFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterBlockFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BlockFriendsRequest) error) error {
+	return registerAfterIn(i, "BlockFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeImportFacebookFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) (*api.ImportFacebookFriendsRequest, error)) error {
+	return registerBefore(i, "ImportFacebookFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterImportFacebookFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportFacebookFriendsRequest) error) error {
+	return registerAfterIn(i, "ImportFacebookFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeImportSteamFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportSteamFriendsRequest) (*api.ImportSteamFriendsRequest, error)) error {
+	return registerBefore(i, "ImportSteamFriends", fn)
+}
+
+func (i *Initializer) RegisterAfterImportSteamFriends(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ImportSteamFriendsRequest) error) error {
+	return registerAfterIn(i, "ImportSteamFriends", fn)
+}
+
+func (i *Initializer) RegisterBeforeCreateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.CreateGroupRequest) (*api.CreateGroupRequest, error)) error {
+	return registerBefore(i, "CreateGroup", fn)
+}
+
+func (i *Initializer) RegisterAfterCreateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Group, in *api.CreateGroupRequest) error) error {
+	return registerAfter(i, "CreateGroup", fn)
+}
+
+func (i *Initializer) RegisterBeforeUpdateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) (*api.UpdateGroupRequest, error)) error {
+	return registerBefore(i, "UpdateGroup", fn)
+}
+
+func (i *Initializer) RegisterAfterUpdateGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.UpdateGroupRequest) error) error {
+	return registerAfterIn(i, "UpdateGroup", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) (*api.DeleteGroupRequest, error)) error {
+	return registerBefore(i, "DeleteGroup", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteGroupRequest) error) error {
+	return registerAfterIn(i, "DeleteGroup", fn)
+}
+
+func (i *Initializer) RegisterBeforeJoinGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) (*api.JoinGroupRequest, error)) error {
+	return registerBefore(i, "JoinGroup", fn)
+}
+
+func (i *Initializer) RegisterAfterJoinGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinGroupRequest) error) error {
+	return registerAfterIn(i, "JoinGroup", fn)
+}
+
+func (i *Initializer) RegisterBeforeLeaveGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) (*api.LeaveGroupRequest, error)) error {
+	return registerBefore(i, "LeaveGroup", fn)
+}
+
+func (i *Initializer) RegisterAfterLeaveGroup(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LeaveGroupRequest) error) error {
+	return registerAfterIn(i, "LeaveGroup", fn)
+}
+
+func (i *Initializer) RegisterBeforeAddGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) (*api.AddGroupUsersRequest, error)) error {
+	return registerBefore(i, "AddGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterAddGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AddGroupUsersRequest) error) error {
+	return registerAfterIn(i, "AddGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeBanGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BanGroupUsersRequest) (*api.BanGroupUsersRequest, error)) error {
+	return registerBefore(i, "BanGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterBanGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.BanGroupUsersRequest) error) error {
+	return registerAfterIn(i, "BanGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeKickGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) (*api.KickGroupUsersRequest, error)) error {
+	return registerBefore(i, "KickGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterKickGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.KickGroupUsersRequest) error) error {
+	return registerAfterIn(i, "KickGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforePromoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) (*api.PromoteGroupUsersRequest, error)) error {
+	return registerBefore(i, "PromoteGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterPromoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.PromoteGroupUsersRequest) error) error {
+	return registerAfterIn(i, "PromoteGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeDemoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DemoteGroupUsersRequest) (*api.DemoteGroupUsersRequest, error)) error {
+	return registerBefore(i, "DemoteGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterDemoteGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DemoteGroupUsersRequest) error) error {
+	return registerAfterIn(i, "DemoteGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeListGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupUsersRequest) (*api.ListGroupUsersRequest, error)) error {
+	return registerBefore(i, "ListGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterListGroupUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupUserList, in *api.ListGroupUsersRequest) error) error {
+	return registerAfter(i, "ListGroupUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeListUserGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListUserGroupsRequest) (*api.ListUserGroupsRequest, error)) error {
+	return registerBefore(i, "ListUserGroups", fn)
+}
+
+func (i *Initializer) RegisterAfterListUserGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.UserGroupList, in *api.ListUserGroupsRequest) error) error {
+	return registerAfter(i, "ListUserGroups", fn)
+}
+
+func (i *Initializer) RegisterBeforeListGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListGroupsRequest) (*api.ListGroupsRequest, error)) error {
+	return registerBefore(i, "ListGroups", fn)
+}
+
+func (i *Initializer) RegisterAfterListGroups(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.GroupList, in *api.ListGroupsRequest) error) error {
+	return registerAfter(i, "ListGroups", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) (*api.DeleteLeaderboardRecordRequest, error)) error {
+	return registerBefore(i, "DeleteLeaderboardRecord", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteLeaderboardRecordRequest) error) error {
+	return registerAfterIn(i, "DeleteLeaderboardRecord", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteTournamentRecordRequest) (*api.DeleteTournamentRecordRequest, error)) error {
+	return registerBefore(i, "DeleteTournamentRecord", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteTournamentRecordRequest) error) error {
+	return registerAfterIn(i, "DeleteTournamentRecord", fn)
+}
+
+func (i *Initializer) RegisterBeforeListLeaderboardRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsRequest) (*api.ListLeaderboardRecordsRequest, error)) error {
+	return registerBefore(i, "ListLeaderboardRecords", fn)
+}
+
+func (i *Initializer) RegisterAfterListLeaderboardRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsRequest) error) error {
+	return registerAfter(i, "ListLeaderboardRecords", fn)
+}
+
+func (i *Initializer) RegisterBeforeWriteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteLeaderboardRecordRequest) (*api.WriteLeaderboardRecordRequest, error)) error {
+	return registerBefore(i, "WriteLeaderboardRecord", fn)
+}
+
+func (i *Initializer) RegisterAfterWriteLeaderboardRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteLeaderboardRecordRequest) error) error {
+	return registerAfter(i, "WriteLeaderboardRecord", fn)
+}
+
+func (i *Initializer) RegisterBeforeListLeaderboardRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.ListLeaderboardRecordsAroundOwnerRequest, error)) error {
+	return registerBefore(i, "ListLeaderboardRecordsAroundOwner", fn)
+}
+
+func (i *Initializer) RegisterAfterListLeaderboardRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecordList, in *api.ListLeaderboardRecordsAroundOwnerRequest) error) error {
+	return registerAfter(i, "ListLeaderboardRecordsAroundOwner", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountApple) (*api.AccountApple, error)) error {
+	return registerBefore(i, "LinkApple", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountApple) error) error {
+	return registerAfterIn(i, "LinkApple", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)) error {
+	return registerBefore(i, "LinkCustom", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error) error {
+	return registerAfterIn(i, "LinkCustom", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)) error {
+	return registerBefore(i, "LinkDevice", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error) error {
+	return registerAfterIn(i, "LinkDevice", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)) error {
+	return registerBefore(i, "LinkEmail", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error) error {
+	return registerAfterIn(i, "LinkEmail", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) (*api.LinkFacebookRequest, error)) error {
+	return registerBefore(i, "LinkFacebook", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkFacebookRequest) error) error {
+	return registerAfterIn(i, "LinkFacebook", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebookInstantGame) (*api.AccountFacebookInstantGame, error)) error {
+	return registerBefore(i, "LinkFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebookInstantGame) error) error {
+	return registerAfterIn(i, "LinkFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)) error {
+	return registerBefore(i, "LinkGameCenter", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error) error {
+	return registerAfterIn(i, "LinkGameCenter", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)) error {
+	return registerBefore(i, "LinkGoogle", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error) error {
+	return registerAfterIn(i, "LinkGoogle", fn)
+}
+
+func (i *Initializer) RegisterBeforeLinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkSteamRequest) (*api.LinkSteamRequest, error)) error {
+	return registerBefore(i, "LinkSteam", fn)
+}
+
+func (i *Initializer) RegisterAfterLinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.LinkSteamRequest) error) error {
+	return registerAfterIn(i, "LinkSteam", fn)
+}
+
+func (i *Initializer) RegisterBeforeListMatches(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListMatchesRequest) (*api.ListMatchesRequest, error)) error {
+	return registerBefore(i, "ListMatches", fn)
+}
+
+func (i *Initializer) RegisterAfterListMatches(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchList, in *api.ListMatchesRequest) error) error {
+	return registerAfter(i, "ListMatches", fn)
+}
+
+func (i *Initializer) RegisterBeforeGetMatchmakerStats(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error) error {
+	return registerBeforeEmpty(i, "GetMatchmakerStats", fn)
+}
+
+func (i *Initializer) RegisterAfterGetMatchmakerStats(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.MatchmakerStats) error) error {
+	return registerAfterOut(i, "GetMatchmakerStats", fn)
+}
+
+func (i *Initializer) RegisterBeforeListNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListNotificationsRequest) (*api.ListNotificationsRequest, error)) error {
+	return registerBefore(i, "ListNotifications", fn)
+}
+
+func (i *Initializer) RegisterAfterListNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.NotificationList, in *api.ListNotificationsRequest) error) error {
+	return registerAfter(i, "ListNotifications", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) (*api.DeleteNotificationsRequest, error)) error {
+	return registerBefore(i, "DeleteNotifications", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteNotifications(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteNotificationsRequest) error) error {
+	return registerAfterIn(i, "DeleteNotifications", fn)
+}
+
+func (i *Initializer) RegisterBeforeListStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListStorageObjectsRequest) (*api.ListStorageObjectsRequest, error)) error {
+	return registerBefore(i, "ListStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterAfterListStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectList, in *api.ListStorageObjectsRequest) error) error {
+	return registerAfter(i, "ListStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterBeforeReadStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ReadStorageObjectsRequest) (*api.ReadStorageObjectsRequest, error)) error {
+	return registerBefore(i, "ReadStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterAfterReadStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjects, in *api.ReadStorageObjectsRequest) error) error {
+	return registerAfter(i, "ReadStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterBeforeWriteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteStorageObjectsRequest) (*api.WriteStorageObjectsRequest, error)) error {
+	return registerBefore(i, "WriteStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterAfterWriteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.StorageObjectAcks, in *api.WriteStorageObjectsRequest) error) error {
+	return registerAfter(i, "WriteStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterBeforeDeleteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) (*api.DeleteStorageObjectsRequest, error)) error {
+	return registerBefore(i, "DeleteStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterAfterDeleteStorageObjects(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.DeleteStorageObjectsRequest) error) error {
+	return registerAfterIn(i, "DeleteStorageObjects", fn)
+}
+
+func (i *Initializer) RegisterBeforeJoinTournament(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) (*api.JoinTournamentRequest, error)) error {
+	return registerBefore(i, "JoinTournament", fn)
+}
+
+func (i *Initializer) RegisterAfterJoinTournament(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.JoinTournamentRequest) error) error {
+	return registerAfterIn(i, "JoinTournament", fn)
+}
+
+func (i *Initializer) RegisterBeforeListTournamentRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsRequest) (*api.ListTournamentRecordsRequest, error)) error {
+	return registerBefore(i, "ListTournamentRecords", fn)
+}
+
+func (i *Initializer) RegisterAfterListTournamentRecords(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsRequest) error) error {
+	return registerAfter(i, "ListTournamentRecords", fn)
+}
+
+func (i *Initializer) RegisterBeforeListTournaments(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentsRequest) (*api.ListTournamentsRequest, error)) error {
+	return registerBefore(i, "ListTournaments", fn)
+}
+
+func (i *Initializer) RegisterAfterListTournaments(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentList, in *api.ListTournamentsRequest) error) error {
+	return registerAfter(i, "ListTournaments", fn)
+}
+
+func (i *Initializer) RegisterBeforeWriteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.WriteTournamentRecordRequest) (*api.WriteTournamentRecordRequest, error)) error {
+	return registerBefore(i, "WriteTournamentRecord", fn)
+}
+
+func (i *Initializer) RegisterAfterWriteTournamentRecord(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.LeaderboardRecord, in *api.WriteTournamentRecordRequest) error) error {
+	return registerAfter(i, "WriteTournamentRecord", fn)
+}
+
+func (i *Initializer) RegisterBeforeListTournamentRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListTournamentRecordsAroundOwnerRequest) (*api.ListTournamentRecordsAroundOwnerRequest, error)) error {
+	return registerBefore(i, "ListTournamentRecordsAroundOwner", fn)
+}
+
+func (i *Initializer) RegisterAfterListTournamentRecordsAroundOwner(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.TournamentRecordList, in *api.ListTournamentRecordsAroundOwnerRequest) error) error {
+	return registerAfter(i, "ListTournamentRecordsAroundOwner", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidatePurchaseApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidatePurchaseAppleRequest) (*api.ValidatePurchaseAppleRequest, error)) error {
+	return registerBefore(i, "ValidatePurchaseApple", fn)
+}
+
+func (i *Initializer) RegisterAfterValidatePurchaseApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidatePurchaseResponse, in *api.ValidatePurchaseAppleRequest) error) error {
+	return registerAfter(i, "ValidatePurchaseApple", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidateSubscriptionApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidateSubscriptionAppleRequest) (*api.ValidateSubscriptionAppleRequest, error)) error {
+	return registerBefore(i, "ValidateSubscriptionApple", fn)
+}
+
+func (i *Initializer) RegisterAfterValidateSubscriptionApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidateSubscriptionResponse, in *api.ValidateSubscriptionAppleRequest) error) error {
+	return registerAfter(i, "ValidateSubscriptionApple", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidatePurchaseGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidatePurchaseGoogleRequest) (*api.ValidatePurchaseGoogleRequest, error)) error {
+	return registerBefore(i, "ValidatePurchaseGoogle", fn)
+}
+
+func (i *Initializer) RegisterAfterValidatePurchaseGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidatePurchaseResponse, in *api.ValidatePurchaseGoogleRequest) error) error {
+	return registerAfter(i, "ValidatePurchaseGoogle", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidateSubscriptionGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidateSubscriptionGoogleRequest) (*api.ValidateSubscriptionGoogleRequest, error)) error {
+	return registerBefore(i, "ValidateSubscriptionGoogle", fn)
+}
+
+func (i *Initializer) RegisterAfterValidateSubscriptionGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidateSubscriptionResponse, in *api.ValidateSubscriptionGoogleRequest) error) error {
+	return registerAfter(i, "ValidateSubscriptionGoogle", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidatePurchaseHuawei(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidatePurchaseHuaweiRequest) (*api.ValidatePurchaseHuaweiRequest, error)) error {
+	return registerBefore(i, "ValidatePurchaseHuawei", fn)
+}
+
+func (i *Initializer) RegisterAfterValidatePurchaseHuawei(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidatePurchaseResponse, in *api.ValidatePurchaseHuaweiRequest) error) error {
+	return registerAfter(i, "ValidatePurchaseHuawei", fn)
+}
+
+func (i *Initializer) RegisterBeforeValidatePurchaseFacebookInstant(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ValidatePurchaseFacebookInstantRequest) (*api.ValidatePurchaseFacebookInstantRequest, error)) error {
+	return registerBefore(i, "ValidatePurchaseFacebookInstant", fn)
+}
+
+func (i *Initializer) RegisterAfterValidatePurchaseFacebookInstant(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidatePurchaseResponse, in *api.ValidatePurchaseFacebookInstantRequest) error) error {
+	return registerAfter(i, "ValidatePurchaseFacebookInstant", fn)
+}
+
+func (i *Initializer) RegisterBeforeListSubscriptions(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListSubscriptionsRequest) (*api.ListSubscriptionsRequest, error)) error {
+	return registerBefore(i, "ListSubscriptions", fn)
+}
+
+func (i *Initializer) RegisterAfterListSubscriptions(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.SubscriptionList, in *api.ListSubscriptionsRequest) error) error {
+	return registerAfter(i, "ListSubscriptions", fn)
+}
+
+func (i *Initializer) RegisterBeforeGetSubscription(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetSubscriptionRequest) (*api.GetSubscriptionRequest, error)) error {
+	return registerBefore(i, "GetSubscription", fn)
+}
+
+func (i *Initializer) RegisterAfterGetSubscription(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.ValidatedSubscription, in *api.GetSubscriptionRequest) error) error {
+	return registerAfter(i, "GetSubscription", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountApple) (*api.AccountApple, error)) error {
+	return registerBefore(i, "UnlinkApple", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountApple) error) error {
+	return registerAfterIn(i, "UnlinkApple", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) (*api.AccountCustom, error)) error {
+	return registerBefore(i, "UnlinkCustom", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkCustom(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountCustom) error) error {
+	return registerAfterIn(i, "UnlinkCustom", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) (*api.AccountDevice, error)) error {
+	return registerBefore(i, "UnlinkDevice", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkDevice(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountDevice) error) error {
+	return registerAfterIn(i, "UnlinkDevice", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) (*api.AccountEmail, error)) error {
+	return registerBefore(i, "UnlinkEmail", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkEmail(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountEmail) error) error {
+	return registerAfterIn(i, "UnlinkEmail", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) (*api.AccountFacebook, error)) error {
+	return registerBefore(i, "UnlinkFacebook", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkFacebook(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebook) error) error {
+	return registerAfterIn(i, "UnlinkFacebook", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebookInstantGame) (*api.AccountFacebookInstantGame, error)) error {
+	return registerBefore(i, "UnlinkFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkFacebookInstantGame(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountFacebookInstantGame) error) error {
+	return registerAfterIn(i, "UnlinkFacebookInstantGame", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) (*api.AccountGameCenter, error)) error {
+	return registerBefore(i, "UnlinkGameCenter", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkGameCenter(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGameCenter) error) error {
+	return registerAfterIn(i, "UnlinkGameCenter", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) (*api.AccountGoogle, error)) error {
+	return registerBefore(i, "UnlinkGoogle", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountGoogle) error) error {
+	return registerAfterIn(i, "UnlinkGoogle", fn)
+}
+
+func (i *Initializer) RegisterBeforeUnlinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) (*api.AccountSteam, error)) error {
+	return registerBefore(i, "UnlinkSteam", fn)
+}
+
+func (i *Initializer) RegisterAfterUnlinkSteam(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AccountSteam) error) error {
+	return registerAfterIn(i, "UnlinkSteam", fn)
+}
+
+func (i *Initializer) RegisterBeforeGetUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.GetUsersRequest) (*api.GetUsersRequest, error)) error {
+	return registerBefore(i, "GetUsers", fn)
+}
+
+func (i *Initializer) RegisterAfterGetUsers(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Users, in *api.GetUsersRequest) error) error {
+	return registerAfter(i, "GetUsers", fn)
+}
+
+func (i *Initializer) RegisterBeforeListParties(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.ListPartiesRequest) (*api.ListPartiesRequest, error)) error {
+	return registerBefore(i, "ListParties", fn)
+}
+
+func (i *Initializer) RegisterAfterListParties(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.PartyList, in *api.ListPartiesRequest) error) error {
+	return registerAfter(i, "ListParties", fn)
+}
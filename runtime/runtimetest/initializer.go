@@ -0,0 +1,463 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// ErrDuplicateRegistration is returned when a function is registered more than once for the same RPC ID, hook,
+// match name or other key that the server only allows a single function for.
+var ErrDuplicateRegistration = errors.New("function already registered")
+
+var _ runtime.Initializer = (*Initializer)(nil)
+
+// Registration records a single Register* call. Kind is the registered function type, such as "rpc", "before",
+// "after", "before_rt", "after_rt", "match", "event" or "http", and ID is the RPC ID, hook name, match name, index
+// name or path the function was registered for. Kinds that take no key, such as "matchmaker_matched", have no ID.
+type Registration struct {
+	Kind string
+	ID   string
+}
+
+// StorageIndex holds the arguments of a RegisterStorageIndex call.
+type StorageIndex struct {
+	Name           string
+	Collection     string
+	Key            string
+	Fields         []string
+	SortableFields []string
+	MaxEntries     int
+	IndexOnly      bool
+}
+
+// Session describes the caller that registered functions are invoked for. Its fields populate the matching
+// RUNTIME_CTX_* context values. A nil Session invokes functions as the server, with no user in the context.
+type Session struct {
+	UserID      string
+	Username    string
+	Vars        map[string]string
+	Expiry      int64
+	SessionID   string
+	Lang        string
+	ClientIP    string
+	ClientPort  string
+	Headers     map[string][]string
+	QueryParams map[string][]string
+}
+
+type (
+	rpcFunction      = func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)
+	beforeFunction   = func(ctx context.Context, in any) (any, error)
+	afterFunction    = func(ctx context.Context, out, in any) error
+	beforeRtFunction = func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *rtapi.Envelope) (*rtapi.Envelope, error)
+	afterRtFunction  = func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out, in *rtapi.Envelope) error
+	matchFunction    = func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error)
+	eventFunction    = func(ctx context.Context, logger runtime.Logger, evt *api.Event)
+)
+
+/*
+Initializer is a runtime.Initializer that records the functions registered by a module's InitModule, and invokes
+them with a context populated the way the server would.
+
+	initializer := runtimetest.NewInitializer(runtimetest.NewNakamaModule())
+	if err := InitModule(ctx, initializer.Logger, nil, initializer.NK, initializer); err != nil {
+		t.Fatal(err)
+	}
+	result, err := initializer.Rpc(ctx, &runtimetest.Session{UserID: userID}, "reward", "{}")
+
+RPC IDs, hook names and realtime message names are case-insensitive, as on the server. Registering a second
+function for a key that already has one returns ErrDuplicateRegistration. Events are the exception: any number of
+event functions may be registered and all of them are invoked.
+*/
+type Initializer struct {
+	Logger  runtime.Logger
+	DB      *sql.DB
+	NK      runtime.NakamaModule
+	Config  runtime.Config
+	Env     map[string]string
+	Node    string
+	Version string
+	// MatchmakerMaxLatency is the latency threshold MatchmakerMatched chooses the region of the matched entries with,
+	// as the server does with its configured threshold. 0 places no limit on the latency.
+	MatchmakerMaxLatency float32
+
+	mu            sync.Mutex
+	registrations []Registration
+
+	rpcs     map[string]rpcFunction
+	before   map[string]beforeFunction
+	after    map[string]afterFunction
+	beforeRt map[string]beforeRtFunction
+	afterRt  map[string]afterRtFunction
+	matches  map[string]matchFunction
+
+	matchmakerMatched              func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (string, error)
+	matchmakerOverride             func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, candidateMatches [][]runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry
+	matchmakerProcessor            func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) [][]runtime.MatchmakerEntry
+	matchmakerScorer               func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) float64
+	matchEnd                       func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, result *runtime.MatchResult) error
+	matchTickOverrun               func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, stats *runtime.MatchStats, duration time.Duration)
+	tournamentEnd                  func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, end, reset int64) error
+	tournamentReset                func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, end, reset int64) error
+	leaderboardReset               func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, leaderboard *api.Leaderboard, reset int64) error
+	purchaseNotificationApple      func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, payload *runtime.AppleNotificationData) error
+	subscriptionNotificationApple  func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, payload *runtime.AppleNotificationData) error
+	purchaseNotificationGoogle     func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, providerPayload *runtime.PurchaseV2GoogleResponse) error
+	subscriptionNotificationGoogle func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, providerPayload *runtime.SubscriptionV2GoogleResponse) error
+	shutdown                       func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule)
+
+	events             []eventFunction
+	eventSessionStart  []eventFunction
+	eventSessionEnd    []eventFunction
+	storageIndexes     map[string]*StorageIndex
+	ratingRulesets     map[string]*runtime.RatingRuleset
+	storageIndexFilter map[string]func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, write *runtime.StorageWrite) bool
+	fleetManager       runtime.FleetManagerInitializer
+
+	httpRoutes        map[string]bool
+	httpMux           *http.ServeMux
+	consoleHttpRoutes map[string]bool
+	consoleHttpMux    *http.ServeMux
+}
+
+// NewInitializer returns an Initializer that passes nk to the functions it invokes, along with a logger that
+// discards all output and a nil database handle.
+func NewInitializer(nk runtime.NakamaModule) *Initializer {
+	return &Initializer{
+		Logger:  NewLogger(nil),
+		NK:      nk,
+		Env:     make(map[string]string),
+		Node:    DefaultNode,
+		Version: "runtimetest",
+
+		rpcs:               make(map[string]rpcFunction),
+		before:             make(map[string]beforeFunction),
+		after:              make(map[string]afterFunction),
+		beforeRt:           make(map[string]beforeRtFunction),
+		afterRt:            make(map[string]afterRtFunction),
+		matches:            make(map[string]matchFunction),
+		storageIndexes:     make(map[string]*StorageIndex),
+		ratingRulesets:     make(map[string]*runtime.RatingRuleset),
+		storageIndexFilter: make(map[string]func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, write *runtime.StorageWrite) bool),
+		httpRoutes:         make(map[string]bool),
+		httpMux:            http.NewServeMux(),
+		consoleHttpRoutes:  make(map[string]bool),
+		consoleHttpMux:     http.NewServeMux(),
+	}
+}
+
+// Registrations returns every successful Register* call in the order they were made.
+func (i *Initializer) Registrations() []Registration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return slices.Clone(i.registrations)
+}
+
+// StorageIndexes returns the registered storage indexes in the order they were registered.
+func (i *Initializer) StorageIndexes() []StorageIndex {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	indexes := make([]StorageIndex, 0, len(i.storageIndexes))
+	for _, registration := range i.registrations {
+		if registration.Kind == "storage_index" {
+			indexes = append(indexes, *i.storageIndexes[registration.ID])
+		}
+	}
+	return indexes
+}
+
+// RatingRulesets returns the registered rating rulesets in the order they were registered.
+func (i *Initializer) RatingRulesets() []runtime.RatingRuleset {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	rulesets := make([]runtime.RatingRuleset, 0, len(i.ratingRulesets))
+	for _, registration := range i.registrations {
+		if registration.Kind == "rating_ruleset" {
+			rulesets = append(rulesets, *i.ratingRulesets[registration.ID])
+		}
+	}
+	return rulesets
+}
+
+// FleetManager returns the registered fleet manager, or nil if none was registered.
+func (i *Initializer) FleetManager() runtime.FleetManagerInitializer {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.fleetManager
+}
+
+// register records a registration, calling set to store the function unless exists reports that one is
+// already registered.
+func (i *Initializer) register(kind, id string, exists func() bool, set func()) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if exists != nil && exists() {
+		if id == "" {
+			return fmt.Errorf("%w: %v", ErrDuplicateRegistration, kind)
+		}
+		return fmt.Errorf("%w: %v %q", ErrDuplicateRegistration, kind, id)
+	}
+	set()
+	i.registrations = append(i.registrations, Registration{Kind: kind, ID: id})
+	return nil
+}
+
+func (i *Initializer) context(ctx context.Context, mode runtime.ExecutionMode, session *Session) context.Context {
+	return runtime.NewContext(ctx, newRuntimeContext(i.Env, mode, i.Node, i.Version, session))
+}
+
+// newRuntimeContext returns the RUNTIME_CTX_* values the server sets for the given execution mode and caller.
+func newRuntimeContext(env map[string]string, mode runtime.ExecutionMode, node, version string, session *Session) *runtime.RuntimeContext {
+	env = maps.Clone(env)
+	if env == nil {
+		env = make(map[string]string)
+	}
+	rc := &runtime.RuntimeContext{
+		Env:     runtime.ContextValueOf(env),
+		Mode:    runtime.ContextValueOf(mode),
+		Node:    runtime.ContextValueOf(node),
+		Version: runtime.ContextValueOf(version),
+	}
+	if session == nil {
+		return rc
+	}
+	rc.Headers = runtime.ContextValue[map[string][]string]{Value: session.Headers, Present: session.Headers != nil}
+	rc.QueryParams = runtime.ContextValue[map[string][]string]{Value: session.QueryParams, Present: session.QueryParams != nil}
+	if session.UserID != "" {
+		rc.UserID = runtime.ContextValueOf(session.UserID)
+		rc.Username = runtime.ContextValueOf(session.Username)
+		rc.Vars = runtime.ContextValueOf(session.Vars)
+		rc.UserSessionExp = runtime.ContextValueOf(session.Expiry)
+	}
+	rc.SessionID = runtime.ContextValue[string]{Value: session.SessionID, Present: session.SessionID != ""}
+	rc.Lang = runtime.ContextValue[string]{Value: session.Lang, Present: session.Lang != ""}
+	if session.ClientIP != "" {
+		rc.ClientIP = runtime.ContextValueOf(session.ClientIP)
+		rc.ClientPort = runtime.ContextValueOf(session.ClientPort)
+	}
+	return rc
+}
+
+func (i *Initializer) GetConfig() (runtime.Config, error) {
+	return i.Config, nil
+}
+
+func (i *Initializer) RegisterRpc(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error)) error {
+	id = strings.ToLower(id)
+	if id == "" {
+		return errors.New("expects rpc id")
+	}
+	return i.register("rpc", id, func() bool { return i.rpcs[id] != nil }, func() { i.rpcs[id] = fn })
+}
+
+func (i *Initializer) RegisterBeforeRt(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *rtapi.Envelope) (*rtapi.Envelope, error)) error {
+	id = strings.ToLower(id)
+	return i.register("before_rt", id, func() bool { return i.beforeRt[id] != nil }, func() { i.beforeRt[id] = fn })
+}
+
+func (i *Initializer) RegisterAfterRt(id string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out, in *rtapi.Envelope) error) error {
+	id = strings.ToLower(id)
+	return i.register("after_rt", id, func() bool { return i.afterRt[id] != nil }, func() { i.afterRt[id] = fn })
+}
+
+func (i *Initializer) RegisterMatchmakerMatched(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (string, error)) error {
+	return i.register("matchmaker_matched", "", func() bool { return i.matchmakerMatched != nil }, func() { i.matchmakerMatched = fn })
+}
+
+func (i *Initializer) RegisterMatchmakerOverride(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, candidateMatches [][]runtime.MatchmakerEntry) (matches [][]runtime.MatchmakerEntry)) error {
+	return i.register("matchmaker_override", "", func() bool { return i.matchmakerOverride != nil }, func() { i.matchmakerOverride = fn })
+}
+
+func (i *Initializer) RegisterMatchmakerProcessor(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (matches [][]runtime.MatchmakerEntry)) error {
+	return i.register("matchmaker_processor", "", func() bool { return i.matchmakerProcessor != nil }, func() { i.matchmakerProcessor = fn })
+}
+
+func (i *Initializer) RegisterMatchmakerScorer(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) float64) error {
+	return i.register("matchmaker_scorer", "", func() bool { return i.matchmakerScorer != nil }, func() { i.matchmakerScorer = fn })
+}
+
+func (i *Initializer) RegisterMatch(name string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error)) error {
+	if name == "" {
+		return errors.New("expects match name")
+	}
+	return i.register("match", name, func() bool { return i.matches[name] != nil }, func() { i.matches[name] = fn })
+}
+
+func (i *Initializer) RegisterMatchEnd(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, result *runtime.MatchResult) error) error {
+	return i.register("match_end", "", func() bool { return i.matchEnd != nil }, func() { i.matchEnd = fn })
+}
+
+func (i *Initializer) RegisterMatchTickOverrun(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, stats *runtime.MatchStats, duration time.Duration)) error {
+	return i.register("match_tick_overrun", "", func() bool { return i.matchTickOverrun != nil }, func() { i.matchTickOverrun = fn })
+}
+
+func (i *Initializer) RegisterTournamentEnd(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, end, reset int64) error) error {
+	return i.register("tournament_end", "", func() bool { return i.tournamentEnd != nil }, func() { i.tournamentEnd = fn })
+}
+
+func (i *Initializer) RegisterTournamentReset(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *api.Tournament, end, reset int64) error) error {
+	return i.register("tournament_reset", "", func() bool { return i.tournamentReset != nil }, func() { i.tournamentReset = fn })
+}
+
+func (i *Initializer) RegisterLeaderboardReset(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, leaderboard *api.Leaderboard, reset int64) error) error {
+	return i.register("leaderboard_reset", "", func() bool { return i.leaderboardReset != nil }, func() { i.leaderboardReset = fn })
+}
+
+func (i *Initializer) RegisterPurchaseNotificationApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, payload *runtime.AppleNotificationData) error) error {
+	return i.register("purchase_notification_apple", "", func() bool { return i.purchaseNotificationApple != nil }, func() { i.purchaseNotificationApple = fn })
+}
+
+func (i *Initializer) RegisterSubscriptionNotificationApple(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, payload *runtime.AppleNotificationData) error) error {
+	return i.register("subscription_notification_apple", "", func() bool { return i.subscriptionNotificationApple != nil }, func() { i.subscriptionNotificationApple = fn })
+}
+
+func (i *Initializer) RegisterPurchaseNotificationGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, purchase *api.ValidatedPurchase, providerPayload *runtime.PurchaseV2GoogleResponse) error) error {
+	return i.register("purchase_notification_google", "", func() bool { return i.purchaseNotificationGoogle != nil }, func() { i.purchaseNotificationGoogle = fn })
+}
+
+func (i *Initializer) RegisterSubscriptionNotificationGoogle(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, notificationType runtime.NotificationType, subscription *api.ValidatedSubscription, providerPayload *runtime.SubscriptionV2GoogleResponse) error) error {
+	return i.register("subscription_notification_google", "", func() bool { return i.subscriptionNotificationGoogle != nil }, func() { i.subscriptionNotificationGoogle = fn })
+}
+
+func (i *Initializer) RegisterEvent(fn func(ctx context.Context, logger runtime.Logger, evt *api.Event)) error {
+	return i.register("event", "", nil, func() { i.events = append(i.events, fn) })
+}
+
+func (i *Initializer) RegisterEventSessionStart(fn func(ctx context.Context, logger runtime.Logger, evt *api.Event)) error {
+	return i.register("event_session_start", "", nil, func() { i.eventSessionStart = append(i.eventSessionStart, fn) })
+}
+
+func (i *Initializer) RegisterEventSessionEnd(fn func(ctx context.Context, logger runtime.Logger, evt *api.Event)) error {
+	return i.register("event_session_end", "", nil, func() { i.eventSessionEnd = append(i.eventSessionEnd, fn) })
+}
+
+func (i *Initializer) RegisterStorageIndex(name, collection, key string, fields []string, sortableFields []string, maxEntries int, indexOnly bool) error {
+	if name == "" {
+		return errors.New("expects index name")
+	}
+	if maxEntries < 1 {
+		return errors.New("expects max entries to be greater than 0")
+	}
+	index := &StorageIndex{
+		Name:           name,
+		Collection:     collection,
+		Key:            key,
+		Fields:         slices.Clone(fields),
+		SortableFields: slices.Clone(sortableFields),
+		MaxEntries:     maxEntries,
+		IndexOnly:      indexOnly,
+	}
+	return i.register("storage_index", name, func() bool { return i.storageIndexes[name] != nil }, func() { i.storageIndexes[name] = index })
+}
+
+func (i *Initializer) RegisterRatingRuleset(ruleset *runtime.RatingRuleset) error {
+	if ruleset == nil || ruleset.ID == "" {
+		return errors.New("expects ruleset id")
+	}
+	r := *ruleset
+	if err := i.register("rating_ruleset", r.ID, func() bool { return i.ratingRulesets[r.ID] != nil }, func() { i.ratingRulesets[r.ID] = &r }); err != nil {
+		return err
+	}
+	if nk, ok := i.NK.(*NakamaModule); ok {
+		nk.AddRatingRuleset(&r)
+	}
+	return nil
+}
+
+func (i *Initializer) RegisterStorageIndexFilter(indexName string, fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, write *runtime.StorageWrite) bool) error {
+	return i.register("storage_index_filter", indexName, func() bool { return i.storageIndexFilter[indexName] != nil }, func() { i.storageIndexFilter[indexName] = fn })
+}
+
+func (i *Initializer) RegisterFleetManager(fleetManagerInit runtime.FleetManagerInitializer) error {
+	if fleetManagerInit == nil {
+		return errors.New("expects fleet manager")
+	}
+	return i.register("fleet_manager", "", func() bool { return i.fleetManager != nil }, func() { i.fleetManager = fleetManagerInit })
+}
+
+func (i *Initializer) RegisterShutdown(fn func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule)) error {
+	return i.register("shutdown", "", func() bool { return i.shutdown != nil }, func() { i.shutdown = fn })
+}
+
+func (i *Initializer) RegisterHttp(pathPattern string, handler func(http.ResponseWriter, *http.Request), methods ...string) error {
+	return i.registerHttp("http", i.httpMux, i.httpRoutes, pathPattern, handler, methods)
+}
+
+func (i *Initializer) RegisterConsoleHttp(pathPattern string, handler func(http.ResponseWriter, *http.Request), methods ...string) error {
+	return i.registerHttp("console_http", i.consoleHttpMux, i.consoleHttpRoutes, pathPattern, handler, methods)
+}
+
+func (i *Initializer) registerHttp(kind string, mux *http.ServeMux, routes map[string]bool, pathPattern string, handler func(http.ResponseWriter, *http.Request), methods []string) error {
+	if !strings.HasPrefix(pathPattern, "/") {
+		return errors.New("expects path pattern to start with '/'")
+	}
+	if handler == nil {
+		return errors.New("expects handler")
+	}
+	patterns := make([]string, 0, len(methods))
+	for _, method := range methods {
+		patterns = append(patterns, strings.ToUpper(method)+" "+pathPattern)
+	}
+	if len(patterns) == 0 {
+		patterns = append(patterns, pathPattern)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if slices.ContainsFunc(patterns, func(pattern string) bool { return routes[pattern] }) {
+		return fmt.Errorf("%w: %v %q", ErrDuplicateRegistration, kind, pathPattern)
+	}
+	if err := handleFunc(mux, patterns, handler); err != nil {
+		return fmt.Errorf("invalid path pattern %q: %v", pathPattern, err)
+	}
+	for _, pattern := range patterns {
+		routes[pattern] = true
+	}
+	i.registrations = append(i.registrations, Registration{Kind: kind, ID: pathPattern})
+	return nil
+}
+
+// handleFunc registers handler for each pattern, recovering from the panic the mux raises for patterns that are
+// malformed or conflict with an earlier registration.
+func handleFunc(mux *http.ServeMux, patterns []string, handler func(http.ResponseWriter, *http.Request)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	for _, pattern := range patterns {
+		mux.HandleFunc(pattern, handler)
+	}
+	return nil
+}
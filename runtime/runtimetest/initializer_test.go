@@ -0,0 +1,219 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+func TestInitializerRpc(t *testing.T) {
+	ctx := context.Background()
+	initializer := NewInitializer(NewNakamaModule())
+	initializer.Env["region"] = "eu"
+
+	rpc := func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		mode, _ := ctx.Value(runtime.RUNTIME_CTX_MODE).(string)
+		env, _ := ctx.Value(runtime.RUNTIME_CTX_ENV).(map[string]string)
+		return mode + ":" + userID + ":" + env["region"] + ":" + payload, nil
+	}
+	if err := initializer.RegisterRpc("Echo", rpc); err != nil {
+		t.Fatal(err)
+	}
+	if err := initializer.RegisterRpc("echo", rpc); !errors.Is(err, ErrDuplicateRegistration) {
+		t.Fatalf("expected duplicate registration error, got %v", err)
+	}
+
+	result, err := initializer.Rpc(ctx, &Session{UserID: aliceID, Username: "alice"}, "ECHO", "{}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "rpc:" + aliceID + ":eu:{}"; result != expected {
+		t.Fatalf("expected %q, got %q", expected, result)
+	}
+	var runtimeErr *runtime.Error
+	if _, err := initializer.Rpc(ctx, nil, "missing", ""); !errors.As(err, &runtimeErr) || runtimeErr.Code != 5 {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if registrations := initializer.Registrations(); len(registrations) != 1 || registrations[0] != (Registration{Kind: "rpc", ID: "echo"}) {
+		t.Fatalf("unexpected registrations %v", registrations)
+	}
+}
+
+func TestInitializerHooks(t *testing.T) {
+	ctx := context.Background()
+	initializer := NewInitializer(NewNakamaModule())
+
+	if err := initializer.RegisterBeforeAuthenticateCustom(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *api.AuthenticateCustomRequest) (*api.AuthenticateCustomRequest, error) {
+		if in.Account.Id == "banned" {
+			return nil, nil
+		}
+		in.Username = "renamed"
+		return in, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var afterMode string
+	if err := initializer.RegisterAfterGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, out *api.Account) error {
+		afterMode, _ = ctx.Value(runtime.RUNTIME_CTX_MODE).(string)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := BeforeHook(ctx, initializer, nil, "AuthenticateCustom", &api.AuthenticateCustomRequest{Account: &api.AccountCustom{Id: "custom"}})
+	if err != nil || in.Username != "renamed" {
+		t.Fatalf("expected request to be changed by hook, got %v, %v", in, err)
+	}
+	if in, err = BeforeHook(ctx, initializer, nil, "AuthenticateCustom", &api.AuthenticateCustomRequest{Account: &api.AccountCustom{Id: "banned"}}); err != nil || in != nil {
+		t.Fatalf("expected request to be rejected by hook, got %v, %v", in, err)
+	}
+	if result, err := initializer.Before(ctx, nil, "AuthenticateCustom", &api.AuthenticateCustomRequest{Account: &api.AccountCustom{Id: "banned"}}); err != nil || result != nil {
+		t.Fatalf("expected a rejected request to be a nil result, got %#v, %v", result, err)
+	}
+	if _, err = initializer.Before(ctx, nil, "AuthenticateCustom", &api.AuthenticateDeviceRequest{}); err == nil {
+		t.Fatal("expected request of the wrong type to fail")
+	}
+	if err = initializer.After(ctx, nil, "GetAccount", &api.Account{}, nil); err != nil || afterMode != "after" {
+		t.Fatalf("expected after hook to run in after mode, got %q, %v", afterMode, err)
+	}
+
+	if err := initializer.RegisterBeforeGetAccount(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) error {
+		if userID, _ := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string); userID == bobID {
+			return runtime.NewError("account locked", 7)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := initializer.Before(ctx, &Session{UserID: aliceID, Username: "alice"}, "GetAccount", nil); err != nil || result == nil {
+		t.Fatalf("expected request without input to be accepted, got %v, %v", result, err)
+	}
+	if _, err := initializer.Before(ctx, &Session{UserID: bobID, Username: "bob"}, "GetAccount", nil); err == nil {
+		t.Fatal("expected request without input to be rejected")
+	}
+	if result, err := initializer.Before(ctx, nil, "DeleteAccount", nil); err != nil || result == nil {
+		t.Fatalf("expected request without input or hook to be accepted, got %v, %v", result, err)
+	}
+
+	if err := initializer.RegisterBeforeRt("ChannelJoin", func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, in *rtapi.Envelope) (*rtapi.Envelope, error) {
+		return nil, runtime.NewError("channels are disabled", 7)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := initializer.BeforeRt(ctx, nil, &rtapi.Envelope{Message: &rtapi.Envelope_ChannelJoin{ChannelJoin: &rtapi.ChannelJoin{}}}); err == nil {
+		t.Fatal("expected realtime hook to reject the message")
+	}
+	envelope := &rtapi.Envelope{Message: &rtapi.Envelope_Ping{Ping: &rtapi.Ping{}}}
+	if out, err := initializer.BeforeRt(ctx, nil, envelope); err != nil || out != envelope {
+		t.Fatalf("expected message without a hook to pass through, got %v, %v", out, err)
+	}
+}
+
+func TestInitializerHttp(t *testing.T) {
+	initializer := NewInitializer(NewNakamaModule())
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.PathValue("id")))
+	}
+	if err := initializer.RegisterHttp("/items/{id}", handler, "get"); err != nil {
+		t.Fatal(err)
+	}
+	if err := initializer.RegisterHttp("/items/{id}", handler, "GET"); !errors.Is(err, ErrDuplicateRegistration) {
+		t.Fatalf("expected duplicate registration error, got %v", err)
+	}
+	if err := initializer.RegisterHttp("/items/{key}", handler, "GET"); err == nil {
+		t.Fatal("expected conflicting path pattern to be rejected")
+	}
+
+	recorder := httptest.NewRecorder()
+	initializer.Http().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/items/sword", nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "sword" {
+		t.Fatalf("unexpected response %v %q", recorder.Code, recorder.Body.String())
+	}
+	recorder = httptest.NewRecorder()
+	initializer.Http().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/items/sword", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected method not allowed, got %v", recorder.Code)
+	}
+}
+
+func TestInitializerMatchmakerScorer(t *testing.T) {
+	ctx := context.Background()
+	initializer := NewInitializer(NewNakamaModule())
+
+	entry := func(skill float64) runtime.MatchmakerEntry {
+		return &MatchmakerEntry{Ticket: generateID(), Properties: map[string]interface{}{"skill": skill}}
+	}
+	narrow := []runtime.MatchmakerEntry{entry(1490), entry(1510)}
+	wide := []runtime.MatchmakerEntry{entry(1200), entry(1800)}
+	if ranked := initializer.MatchmakerRank(ctx, [][]runtime.MatchmakerEntry{wide, narrow}); ranked[0][0] != wide[0] {
+		t.Fatal("expected groupings to keep their order without a scorer")
+	}
+
+	var mode string
+	if err := initializer.RegisterMatchmakerScorer(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) float64 {
+		mode, _ = ctx.Value(runtime.RUNTIME_CTX_MODE).(string)
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, e := range entries {
+			skill := e.GetProperties()["skill"].(float64)
+			low, high = math.Min(low, skill), math.Max(high, skill)
+		}
+		return -(high - low)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if score := initializer.MatchmakerScore(ctx, narrow); score != -20 || mode != "matchmaker_scorer" {
+		t.Fatalf("unexpected score %v in mode %q", score, mode)
+	}
+	if ranked := initializer.MatchmakerRank(ctx, [][]runtime.MatchmakerEntry{wide, narrow}); ranked[0][0] != narrow[0] || ranked[1][0] != wide[0] {
+		t.Fatal("expected the grouping with the smallest rating spread first")
+	}
+}
+
+func TestInitializerMatchmakerMatched(t *testing.T) {
+	ctx := context.Background()
+	initializer := NewInitializer(NewNakamaModule())
+	initializer.MatchmakerMaxLatency = 80
+
+	var region string
+	if err := initializer.RegisterMatchmakerMatched(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (string, error) {
+		region, _ = runtime.FromContext(ctx).MatchmakerRegion.Get()
+		return "match", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []runtime.MatchmakerEntry{
+		&MatchmakerEntry{Presence: NewPresence(aliceID, "alice"), Latencies: map[string]float32{"eu": 30, "us": 70}},
+		&MatchmakerEntry{Presence: NewPresence(bobID, "bob"), Latencies: map[string]float32{"eu": 90, "us": 50}},
+	}
+	if matchID, err := initializer.MatchmakerMatched(ctx, entries); err != nil || matchID != "match" || region != "us" {
+		t.Fatalf("expected match in the shared region, got %q, %v in %q", matchID, err, region)
+	}
+	initializer.MatchmakerMaxLatency = 60
+	if _, err := initializer.MatchmakerMatched(ctx, entries); runtime.ErrorCodeOf(err) != runtime.ErrorCodeFailedPrecondition {
+		t.Fatalf("expected entries without a shared region to fail, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	leaderboardSortOrderAscending  = 0
+	leaderboardSortOrderDescending = 1
+)
+
+type leaderboard struct {
+	leaderboard *api.Leaderboard
+	position    int64
+	records     map[string]*api.LeaderboardRecord
+}
+
+// ranked returns the records of the leaderboard in rank order, with their rank fields set.
+func (l *leaderboard) ranked() []*api.LeaderboardRecord {
+	records := make([]*api.LeaderboardRecord, 0, len(l.records))
+	for _, record := range l.records {
+		records = append(records, record)
+	}
+	descending := l.leaderboard.SortOrder == leaderboardSortOrderDescending
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Score != b.Score {
+			return (a.Score > b.Score) == descending
+		}
+		if a.Subscore != b.Subscore {
+			return (a.Subscore > b.Subscore) == descending
+		}
+		if !a.UpdateTime.AsTime().Equal(b.UpdateTime.AsTime()) {
+			return a.UpdateTime.AsTime().Before(b.UpdateTime.AsTime())
+		}
+		return a.OwnerId < b.OwnerId
+	})
+	for i, record := range records {
+		record.Rank = int64(i + 1)
+	}
+	return records
+}
+
+func (n *NakamaModule) LeaderboardCreate(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, enableRanks bool) error {
+	if id == "" {
+		return errors.New("expects a leaderboard ID string")
+	}
+	var order uint32
+	switch sortOrder {
+	case "desc", "descending", "":
+		order = leaderboardSortOrderDescending
+	case "asc", "ascending":
+		order = leaderboardSortOrderAscending
+	default:
+		return errors.New("expects sort order to be 'asc' or 'desc'")
+	}
+	var op api.Operator
+	switch operator {
+	case "best", "":
+		op = api.Operator_BEST
+	case "set":
+		op = api.Operator_SET
+	case "incr", "increment":
+		op = api.Operator_INCREMENT
+	case "decr", "decrement":
+		op = api.Operator_DECREMENT
+	default:
+		return errors.New("expects operator to be 'best', 'set', 'decr' or 'incr'")
+	}
+	encoded := []byte("{}")
+	if metadata != nil {
+		var err error
+		if encoded, err = json.Marshal(metadata); err != nil {
+			return fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.leaderboards[id]; ok {
+		return nil
+	}
+	n.leaderboards[id] = &leaderboard{
+		leaderboard: &api.Leaderboard{
+			Id:            id,
+			SortOrder:     order,
+			Operator:      op,
+			Metadata:      string(encoded),
+			CreateTime:    timestamppb.New(n.Now()),
+			Authoritative: authoritative,
+		},
+		position: n.nextSeqLocked(),
+		records:  make(map[string]*api.LeaderboardRecord),
+	}
+	return nil
+}
+
+func (n *NakamaModule) LeaderboardDelete(ctx context.Context, id string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.leaderboards, id)
+	return nil
+}
+
+func (n *NakamaModule) LeaderboardRanksDisable(ctx context.Context, id string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.leaderboards[id]; !ok {
+		return runtime.ErrLeaderboardNotFound
+	}
+	return nil
+}
+
+func (n *NakamaModule) LeaderboardList(limit int, cursor string) (*api.LeaderboardList, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	leaderboards := make([]*leaderboard, 0, len(n.leaderboards))
+	for _, l := range n.leaderboards {
+		leaderboards = append(leaderboards, l)
+	}
+	sort.Slice(leaderboards, func(i, j int) bool { return leaderboards[i].position < leaderboards[j].position })
+
+	start, end, next, err := paginate(cursor, limit, len(leaderboards))
+	if err != nil {
+		return nil, err
+	}
+	list := &api.LeaderboardList{Leaderboards: make([]*api.Leaderboard, 0, end-start), Cursor: next}
+	for _, l := range leaderboards[start:end] {
+		list.Leaderboards = append(list.Leaderboards, proto.Clone(l.leaderboard).(*api.Leaderboard))
+	}
+	return list, nil
+}
+
+func (n *NakamaModule) LeaderboardsGetId(ctx context.Context, ids []string) ([]*api.Leaderboard, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	leaderboards := make([]*api.Leaderboard, 0, len(ids))
+	for _, id := range ids {
+		if l, ok := n.leaderboards[id]; ok {
+			leaderboards = append(leaderboards, proto.Clone(l.leaderboard).(*api.Leaderboard))
+		}
+	}
+	return leaderboards, nil
+}
+
+func (n *NakamaModule) LeaderboardRecordWrite(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}, overrideOperator *int) (*api.LeaderboardRecord, error) {
+	if ownerID == "" {
+		return nil, errors.New("expects owner ID to be a valid identifier")
+	}
+	var encoded []byte
+	if metadata != nil {
+		var err error
+		if encoded, err = json.Marshal(metadata); err != nil {
+			return nil, fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	l, ok := n.leaderboards[id]
+	if !ok {
+		return nil, runtime.ErrLeaderboardNotFound
+	}
+	operator := l.leaderboard.Operator
+	if overrideOperator != nil && *overrideOperator != int(api.Operator_NO_OVERRIDE) {
+		if _, ok := api.Operator_name[int32(*overrideOperator)]; !ok {
+			return nil, errors.New("invalid operator override")
+		}
+		operator = api.Operator(*overrideOperator)
+	}
+
+	now := timestamppb.New(n.Now())
+	record, ok := l.records[ownerID]
+	if !ok {
+		record = &api.LeaderboardRecord{
+			LeaderboardId: id,
+			OwnerId:       ownerID,
+			Metadata:      "{}",
+			CreateTime:    now,
+			ExpiryTime:    &timestamppb.Timestamp{},
+		}
+		if operator == api.Operator_DECREMENT {
+			score, subscore = 0, 0
+		}
+		record.Score, record.Subscore = score, subscore
+		l.records[ownerID] = record
+	} else {
+		switch operator {
+		case api.Operator_SET:
+			record.Score, record.Subscore = score, subscore
+		case api.Operator_INCREMENT:
+			record.Score, record.Subscore = record.Score+score, record.Subscore+subscore
+		case api.Operator_DECREMENT:
+			record.Score, record.Subscore = max(record.Score-score, 0), max(record.Subscore-subscore, 0)
+		default:
+			better := score > record.Score || (score == record.Score && subscore > record.Subscore)
+			if l.leaderboard.SortOrder == leaderboardSortOrderAscending {
+				better = score < record.Score || (score == record.Score && subscore < record.Subscore)
+			}
+			if better {
+				record.Score, record.Subscore = score, subscore
+			}
+		}
+	}
+	if username != "" {
+		record.Username = wrapperspb.String(username)
+	}
+	if encoded != nil {
+		record.Metadata = string(encoded)
+	}
+	record.NumScore++
+	record.UpdateTime = now
+
+	l.ranked()
+	return proto.Clone(record).(*api.LeaderboardRecord), nil
+}
+
+func (n *NakamaModule) LeaderboardRecordDelete(ctx context.Context, id, ownerID string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	l, ok := n.leaderboards[id]
+	if !ok {
+		return runtime.ErrLeaderboardNotFound
+	}
+	delete(l.records, ownerID)
+	return nil
+}
+
+// LeaderboardRecordsList returns a page of records in rank order, and the records of the given owners. When limit
+// is 0 and owner IDs are given only the owner records are returned.
+func (n *NakamaModule) LeaderboardRecordsList(ctx context.Context, id string, ownerIDs []string, limit int, cursor string, expiry int64) ([]*api.LeaderboardRecord, []*api.LeaderboardRecord, string, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	l, ok := n.leaderboards[id]
+	if !ok {
+		return nil, nil, "", "", runtime.ErrLeaderboardNotFound
+	}
+	ranked := l.ranked()
+
+	ownerRecords := make([]*api.LeaderboardRecord, 0, len(ownerIDs))
+	for _, ownerID := range ownerIDs {
+		if record, ok := l.records[ownerID]; ok {
+			ownerRecords = append(ownerRecords, proto.Clone(record).(*api.LeaderboardRecord))
+		}
+	}
+	if limit == 0 && len(ownerIDs) > 0 {
+		return []*api.LeaderboardRecord{}, ownerRecords, "", "", nil
+	}
+
+	start, end, next, err := paginate(cursor, limit, len(ranked))
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	prev := ""
+	if start > 0 {
+		if limit <= 0 {
+			limit = 100
+		}
+		prev = strconv.Itoa(max(start-limit, 0))
+	}
+	records := make([]*api.LeaderboardRecord, 0, end-start)
+	for _, record := range ranked[start:end] {
+		records = append(records, proto.Clone(record).(*api.LeaderboardRecord))
+	}
+	return records, ownerRecords, next, prev, nil
+}
+
+func (n *NakamaModule) LeaderboardRecordsListCursorFromRank(id string, rank, overrideExpiry int64) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.leaderboards[id]; !ok {
+		return "", runtime.ErrLeaderboardNotFound
+	}
+	if rank <= 1 {
+		return "", nil
+	}
+	return strconv.FormatInt(rank-1, 10), nil
+}
+
+// LeaderboardRecordsHaystack returns the records surrounding the record of the owner, in rank order.
+func (n *NakamaModule) LeaderboardRecordsHaystack(ctx context.Context, id, ownerID string, limit int, cursor string, expiry int64) (*api.LeaderboardRecordList, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	l, ok := n.leaderboards[id]
+	if !ok {
+		return nil, runtime.ErrLeaderboardNotFound
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	ranked := l.ranked()
+	list := &api.LeaderboardRecordList{Records: []*api.LeaderboardRecord{}, RankCount: int64(len(ranked))}
+
+	var start int
+	if cursor != "" {
+		var err error
+		if start, err = strconv.Atoi(cursor); err != nil || start < 0 || start > len(ranked) {
+			return nil, errInvalidCursor
+		}
+	} else {
+		record, ok := l.records[ownerID]
+		if !ok {
+			return list, nil
+		}
+		start = max(int(record.Rank)-1-limit/2, 0)
+		start = max(min(start, len(ranked)-limit), 0)
+	}
+	end := min(start+limit, len(ranked))
+	for _, record := range ranked[start:end] {
+		list.Records = append(list.Records, proto.Clone(record).(*api.LeaderboardRecord))
+	}
+	if end < len(ranked) {
+		list.NextCursor = strconv.Itoa(end)
+	}
+	if start > 0 {
+		list.PrevCursor = strconv.Itoa(max(start-limit, 0))
+	}
+	return list, nil
+}
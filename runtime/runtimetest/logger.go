@@ -0,0 +1,81 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+type logger struct {
+	tb     testing.TB
+	fields map[string]interface{}
+}
+
+// NewLogger returns a runtime.Logger that writes to the test log. A nil tb discards all output.
+func NewLogger(tb testing.TB) runtime.Logger {
+	return &logger{tb: tb, fields: make(map[string]interface{})}
+}
+
+func (l *logger) log(level, format string, v ...interface{}) {
+	if l.tb == nil {
+		return
+	}
+	l.tb.Helper()
+
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(fmt.Sprintf(format, v...))
+	for _, key := range slices.Sorted(maps.Keys(l.fields)) {
+		fmt.Fprintf(&b, " %v=%v", key, l.fields[key])
+	}
+	l.tb.Log(b.String())
+}
+
+func (l *logger) Debug(format string, v ...interface{}) {
+	l.log("DEBUG", format, v...)
+}
+
+func (l *logger) Info(format string, v ...interface{}) {
+	l.log("INFO", format, v...)
+}
+
+func (l *logger) Warn(format string, v ...interface{}) {
+	l.log("WARN", format, v...)
+}
+
+func (l *logger) Error(format string, v ...interface{}) {
+	l.log("ERROR", format, v...)
+}
+
+func (l *logger) WithField(key string, v interface{}) runtime.Logger {
+	return l.WithFields(map[string]interface{}{key: v})
+}
+
+func (l *logger) WithFields(fields map[string]interface{}) runtime.Logger {
+	merged := maps.Clone(l.fields)
+	maps.Copy(merged, fields)
+	return &logger{tb: l.tb, fields: merged}
+}
+
+func (l *logger) Fields() map[string]interface{} {
+	return maps.Clone(l.fields)
+}
@@ -0,0 +1,1338 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	// DefaultDeferredQueueSize is the number of deferred broadcasts a match may queue per tick, matching the server's
+	// default match deferred queue size.
+	DefaultDeferredQueueSize = 128
+
+	matchLabelMaxBytes = 2048
+	matchTickRateMin   = 1
+	matchTickRateMax   = 60
+)
+
+var errMatchInitialized = errors.New("match already initialized")
+
+// Broadcast is a message sent through the match dispatcher. Presences holds the recipients the message was
+// delivered to, which is every presence in the match that is not a spectator when the match sent it without a
+// presence list, and every spectator for messages sent through BroadcastSpectators.
+type Broadcast struct {
+	Tick       int64
+	OpCode     int64
+	Data       []byte
+	Presences  []runtime.Presence
+	Sender     runtime.Presence
+	Reliable   bool
+	Deferred   bool
+	Spectators bool
+}
+
+// ScheduledTimer is a timer scheduled through the match dispatcher, due to fire on Tick.
+type ScheduledTimer struct {
+	Key  string
+	Data []byte
+	Tick int64
+}
+
+// Reservation is the slot of a disconnected presence, reserved for its user to rejoin until Expires.
+type Reservation struct {
+	Presence runtime.Presence
+	Expires  time.Time
+}
+
+// MatchBackfill holds the open slots a match set through the dispatcher for the matchmaker to fill, and the query
+// and properties it set with them.
+type MatchBackfill struct {
+	Slots             int
+	Query             string
+	StringProperties  map[string]string
+	NumericProperties map[string]float64
+}
+
+// MatchSnapshot is a checkpoint of a match taken by MatchHarness.Snapshot, holding what the server stores to restore
+// the match: its ID, tick, tick rate, label, presences and the sequence numbers acknowledged to them, timers,
+// reservations and backfill slots, and the state encoded by runtime.MatchSnapshotter.
+type MatchSnapshot struct {
+	ID              string
+	Tick            int64
+	TickRate        int
+	Label           string
+	Presences       []runtime.Presence
+	Acks            map[string]int64
+	Timers          []*ScheduledTimer
+	ReconnectWindow time.Duration
+	Reservations    []*Reservation
+	Backfill        *MatchBackfill
+	Data            []byte
+}
+
+/*
+MatchHarness drives a runtime.Match through its lifecycle without a server. Every call into the match is made
+synchronously by the harness methods, and time only moves forward when the harness runs ticks, so a test controls
+exactly which callbacks run and in what order.
+
+	harness := runtimetest.NewMatchHarness(&Arena{}, runtimetest.NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice := runtimetest.NewPresence(aliceID, "alice")
+	if accepted, reason, err := harness.Join(ctx, alice, nil); !accepted {
+		t.Fatal(reason, err)
+	}
+	harness.Send(alice, opCodeMove, []byte(`{"x":1}`), true)
+	if err := harness.Advance(ctx, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts := harness.Broadcasts()
+
+The harness applies the same limits as the server: tick rates outside 1 to 60 and labels longer than 2048 bytes are
+rejected, and deferred broadcasts beyond DeferredQueueSize per tick fail with ErrDeferredBroadcastFull. A callback
+that returns a nil state or ends the match through MatchDispatcher.MatchEnd stops the match, after which the harness
+methods return ErrMatchNotFound. If NK is a *NakamaModule, the match is registered with it once initialized, so that
+NakamaModule.MatchSend reaches it, and the results recorded through MatchEnd are stored in it.
+*/
+type MatchHarness struct {
+	Logger  runtime.Logger
+	DB      *sql.DB
+	NK      runtime.NakamaModule
+	Env     map[string]string
+	Node    string
+	Version string
+	ID      string
+	// Module is the name the match handler is registered with, reported in its stats.
+	Module string
+	// Start is the time of the first tick. Each tick advances the time seen by the match by 1/tick rate seconds.
+	Start             time.Time
+	DeferredQueueSize int
+	// OnTickOverrun, if set, is called after a tick that took longer than the interval between ticks at the match tick
+	// rate, as Initializer.MatchTickOverrun is on the server.
+	OnTickOverrun func(ctx context.Context, stats *runtime.MatchStats, duration time.Duration)
+
+	match      runtime.Match
+	dispatcher *matchDispatcher
+
+	mu             sync.Mutex
+	state          interface{}
+	initialized    bool
+	stopped        bool
+	tick           int64
+	tickRate       int
+	nextTickRate   int
+	elapsed        time.Duration
+	label          string
+	terminateTick  int64
+	presences      []runtime.Presence
+	timers         []*ScheduledTimer
+	reconnect      time.Duration
+	reservations   []*Reservation
+	backfill       *MatchBackfill
+	spectatorDelay int64
+	spectated      []*spectatorBroadcast
+	scripted       map[int64][]*MatchData
+	acks           map[string]int64
+	result         *runtime.MatchResult
+	ticks          int64
+	tickTotal      time.Duration
+	tickMax        time.Duration
+	overruns       int64
+	received       int64
+	looping        bool
+	sent           int64
+	broadcastBytes int64
+	deferred       []*Broadcast
+	kickPending    []runtime.Presence
+	broadcasts     []*Broadcast
+	kicks          []runtime.Presence
+	labelUpdates   []string
+}
+
+// NewMatchHarness returns a harness for the match, which passes nk to the match callbacks along with a logger that
+// discards all output and a nil database handle.
+func NewMatchHarness(match runtime.Match, nk runtime.NakamaModule) *MatchHarness {
+	h := &MatchHarness{
+		Logger:            NewLogger(nil),
+		NK:                nk,
+		Env:               make(map[string]string),
+		Node:              DefaultNode,
+		Version:           "runtimetest",
+		ID:                generateID() + "." + DefaultNode,
+		Start:             time.Unix(0, 0).UTC(),
+		DeferredQueueSize: DefaultDeferredQueueSize,
+
+		match:         match,
+		terminateTick: -1,
+		scripted:      make(map[int64][]*MatchData),
+		acks:          make(map[string]int64),
+	}
+	h.dispatcher = &matchDispatcher{h: h}
+	return h
+}
+
+// context returns ctx with the RUNTIME_CTX_* values the server sets for match callbacks.
+func (h *MatchHarness) context(ctx context.Context) context.Context {
+	h.mu.Lock()
+	label, tickRate := h.label, h.tickRate
+	h.mu.Unlock()
+
+	rc := newRuntimeContext(h.Env, runtime.ExecutionModeMatch, h.Node, h.Version, nil)
+	rc.MatchID = runtime.ContextValueOf(h.ID)
+	rc.MatchNode = runtime.ContextValueOf(h.Node)
+	// The tick rate is only set once the match has been initialized or is being restored.
+	if tickRate > 0 {
+		rc.MatchLabel = runtime.ContextValueOf(label)
+		rc.MatchTickRate = runtime.ContextValueOf(tickRate)
+	}
+	return runtime.NewContext(ctx, rc)
+}
+
+// running returns ErrMatchNotFound if the match has not been initialized or has stopped.
+func (h *MatchHarness) running() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.initialized || h.stopped {
+		return runtime.ErrMatchNotFound
+	}
+	return nil
+}
+
+// update stores the state returned by a callback, stopping the match if it is nil or the callback ended it through
+// the dispatcher, and then delivers the leaves of any presences the callback kicked.
+func (h *MatchHarness) update(ctx context.Context, state interface{}) {
+	for {
+		h.mu.Lock()
+		h.state = state
+		if state == nil || h.result != nil {
+			h.stopped = true
+		}
+		kicked := h.kickPending
+		h.kickPending = nil
+		tick, stopped := h.tick, h.stopped
+		h.mu.Unlock()
+
+		if stopped || len(kicked) == 0 {
+			return
+		}
+		state = h.match.MatchLeave(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, state, kicked)
+	}
+}
+
+// Init calls MatchInit with the given params.
+func (h *MatchHarness) Init(ctx context.Context, params map[string]interface{}) error {
+	h.mu.Lock()
+	initialized := h.initialized
+	h.mu.Unlock()
+
+	if initialized {
+		return errMatchInitialized
+	}
+	state, tickRate, label := h.match.MatchInit(h.context(ctx), h.Logger, h.DB, h.NK, params)
+	if state == nil {
+		return runtime.ErrMatchStateFailed
+	}
+	if tickRate < matchTickRateMin || tickRate > matchTickRateMax {
+		return runtime.ErrMatchTickRateInvalid
+	}
+	if len(label) > matchLabelMaxBytes {
+		return runtime.ErrMatchLabelTooLong
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.state, h.tickRate, h.label, h.initialized = state, tickRate, label, true
+	h.registerLocked()
+	return nil
+}
+
+// registerLocked registers the match with NK, if it is a *NakamaModule, replacing any match with the same ID.
+func (h *MatchHarness) registerLocked() {
+	if nk, ok := h.NK.(*NakamaModule); ok {
+		nk.mu.Lock()
+		nk.matches[h.ID] = h
+		nk.mu.Unlock()
+	}
+}
+
+// JoinAttempt calls MatchJoinAttempt for the presence and returns whether the match accepted it, without adding the
+// presence to the match. Use Join for the full flow of a client joining.
+func (h *MatchHarness) JoinAttempt(ctx context.Context, presence runtime.Presence, metadata map[string]string) (bool, string, error) {
+	if err := h.running(); err != nil {
+		return false, "", err
+	}
+	state, accepted, reason := h.match.MatchJoinAttempt(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, h.CurrentTick(), h.State(), presence, metadata)
+	h.update(ctx, state)
+	if state == nil {
+		return false, reason, nil
+	}
+	return accepted, reason, nil
+}
+
+// Join calls MatchJoinAttempt for the presence and, if the match accepts it, adds it to the match and calls
+// MatchJoin, as the server does when a client joins. If a slot is reserved for the user of the presence, the presence
+// is added to the match and MatchRejoin is called instead.
+func (h *MatchHarness) Join(ctx context.Context, presence runtime.Presence, metadata map[string]string) (bool, string, error) {
+	if rejoined, err := h.rejoin(ctx, presence); rejoined || err != nil {
+		return rejoined, "", err
+	}
+	accepted, reason, err := h.JoinAttempt(ctx, presence, metadata)
+	if err != nil || !accepted {
+		return false, reason, err
+	}
+	if err := h.running(); err != nil {
+		return false, "", err
+	}
+
+	h.mu.Lock()
+	if !slices.ContainsFunc(h.presences, samePresence(presence)) {
+		h.presences = append(h.presences, presence)
+	}
+	tick := h.tick
+	h.mu.Unlock()
+
+	h.update(ctx, h.match.MatchJoin(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), []runtime.Presence{presence}))
+	return true, reason, nil
+}
+
+// rejoin admits the presence and calls MatchRejoin if its user has a reserved slot, and returns false otherwise.
+func (h *MatchHarness) rejoin(ctx context.Context, presence runtime.Presence) (bool, error) {
+	if err := h.running(); err != nil {
+		return false, err
+	}
+	if !runtime.MatchSupports(h.match, runtime.MatchFeatureReconnect) {
+		return false, nil
+	}
+	reconnector := h.match.(runtime.MatchReconnector)
+
+	h.mu.Lock()
+	i := slices.IndexFunc(h.reservations, func(r *Reservation) bool { return r.Presence.GetUserId() == presence.GetUserId() })
+	if i < 0 {
+		h.mu.Unlock()
+		return false, nil
+	}
+	h.reservations = slices.Delete(h.reservations, i, i+1)
+	h.presences = append(h.presences, presence)
+	tick := h.tick
+	h.mu.Unlock()
+
+	h.update(ctx, reconnector.MatchRejoin(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), presence))
+	return true, nil
+}
+
+// Leave removes the presences from the match and calls MatchLeave with those that were in it.
+func (h *MatchHarness) Leave(ctx context.Context, presences ...runtime.Presence) error {
+	if err := h.running(); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	left := h.removePresencesLocked(presences)
+	tick := h.tick
+	h.mu.Unlock()
+
+	if len(left) == 0 {
+		return nil
+	}
+	h.update(ctx, h.match.MatchLeave(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), left))
+	return nil
+}
+
+// Disconnect removes the presences from the match as if their sessions disconnected, and calls MatchLeave with those
+// that were in it, with PresenceReasonDisconnect as their reason. If the match has set a reconnect window, the slots of
+// the presences are then reserved for their users to rejoin through Join until the window has passed.
+func (h *MatchHarness) Disconnect(ctx context.Context, presences ...runtime.Presence) error {
+	if err := h.running(); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	removed := h.removePresencesLocked(presences)
+	left := make([]runtime.Presence, 0, len(removed))
+	for _, presence := range removed {
+		p := presenceOf(presence)
+		p.Reason = runtime.PresenceReasonDisconnect
+		left = append(left, p)
+	}
+	tick := h.tick
+	h.mu.Unlock()
+
+	if len(left) == 0 {
+		return nil
+	}
+	h.update(ctx, h.match.MatchLeave(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), left))
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.reconnect > 0 && !h.stopped {
+		expires := h.nowLocked().Add(h.reconnect)
+		for _, presence := range left {
+			h.reservations = append(h.reservations, &Reservation{Presence: presence, Expires: expires})
+		}
+	}
+	return nil
+}
+
+// removePresencesLocked removes the presences from the match and returns those that were in it.
+func (h *MatchHarness) removePresencesLocked(presences []runtime.Presence) []runtime.Presence {
+	removed := make([]runtime.Presence, 0, len(presences))
+	for _, presence := range presences {
+		if i := slices.IndexFunc(h.presences, samePresence(presence)); i >= 0 {
+			removed = append(removed, h.presences[i])
+			delete(h.acks, h.presences[i].GetSessionId())
+			h.presences = slices.Delete(h.presences, i, i+1)
+		}
+	}
+	return removed
+}
+
+// Send queues a message from the presence for delivery to MatchLoop on the next tick, which is the tick after the
+// current one when called from inside MatchLoop.
+func (h *MatchHarness) Send(presence runtime.Presence, opCode int64, data []byte, reliable bool) {
+	h.SendAt(h.nextTick(), presence, opCode, data, reliable)
+}
+
+// SendAt queues a message from the presence for delivery to MatchLoop on the given tick. As on the server,
+// messages from presences that are not in the match when the tick runs, or that joined it as spectators, are dropped.
+func (h *MatchHarness) SendAt(tick int64, presence runtime.Presence, opCode int64, data []byte, reliable bool) {
+	h.SendData(tick, &MatchData{
+		Presence: *presenceOf(presence),
+		OpCode:   opCode,
+		Data:     data,
+		Reliable: reliable,
+	})
+}
+
+// SendData queues the message for delivery to MatchLoop on the given tick, for messages that set fields SendAt does
+// not, such as the client sequence number and ack. The receive time is set when the message is delivered. A message
+// with no session ID is delivered as a message from the server, as sent through NakamaModule.MatchSend.
+func (h *MatchHarness) SendData(tick int64, data *MatchData) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.scripted[tick] = append(h.scripted[tick], data)
+}
+
+// Tick fires the timers due on the current tick and runs a single iteration of the match loop with the messages
+// queued for it, then delivers the broadcasts the match deferred during the tick. Once the grace period given to
+// Terminate has passed, Tick stops the match instead.
+func (h *MatchHarness) Tick(ctx context.Context) error {
+	if err := h.running(); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	if h.terminateTick >= 0 && h.tick >= h.terminateTick {
+		h.stopped = true
+		h.mu.Unlock()
+		return nil
+	}
+	h.applyTickRateLocked()
+	start := time.Now()
+	tick := h.tick
+	h.spectated = slices.DeleteFunc(h.spectated, func(s *spectatorBroadcast) bool {
+		if s.due > tick {
+			return false
+		}
+		if broadcast := h.dispatcher.spectatorBroadcastLocked(s.broadcast); broadcast != nil {
+			h.recordBroadcastsLocked(broadcast)
+		}
+		return true
+	})
+	now := h.nowLocked()
+	expired := slices.DeleteFunc(slices.Clone(h.reservations), func(r *Reservation) bool { return r.Expires.After(now) })
+	h.reservations = slices.DeleteFunc(h.reservations, func(r *Reservation) bool { return !r.Expires.After(now) })
+	timers := h.dueTimersLocked(tick)
+	h.mu.Unlock()
+
+	if runtime.MatchSupports(h.match, runtime.MatchFeatureReconnect) {
+		reconnector := h.match.(runtime.MatchReconnector)
+		for _, r := range expired {
+			if h.Stopped() {
+				return nil
+			}
+			h.update(ctx, reconnector.MatchReservationExpired(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), r.Presence))
+		}
+	}
+	if runtime.MatchSupports(h.match, runtime.MatchFeatureTimer) {
+		timer := h.match.(runtime.MatchTimer)
+		for _, t := range timers {
+			if h.Stopped() {
+				return nil
+			}
+			h.update(ctx, timer.MatchTimer(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), t.Key, t.Data))
+		}
+	}
+	if h.Stopped() {
+		return nil
+	}
+
+	h.mu.Lock()
+	receiveTime := h.nowLocked().UnixMilli()
+	messages := make([]runtime.MatchData, 0, len(h.scripted[tick]))
+	for _, message := range h.scripted[tick] {
+		if i := slices.IndexFunc(h.presences, samePresence(&message.Presence)); (i >= 0 && !spectating(h.presences[i])) || message.SessionID == "" {
+			message.ReceiveTime = receiveTime
+			messages = append(messages, message)
+			if message.Sequence > h.acks[message.SessionID] {
+				h.acks[message.SessionID] = message.Sequence
+			}
+		}
+	}
+	delete(h.scripted, tick)
+	h.looping = true
+	h.mu.Unlock()
+
+	state := h.match.MatchLoop(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, tick, h.State(), messages)
+	duration := time.Since(start)
+
+	h.mu.Lock()
+	h.recordBroadcastsLocked(h.deferred...)
+	h.deferred = nil
+	interval := time.Second / time.Duration(h.tickRate)
+	h.ticks++
+	h.tickTotal += duration
+	h.tickMax = max(h.tickMax, duration)
+	h.received += int64(len(messages))
+	overrun := duration > interval
+	if overrun {
+		h.overruns++
+	}
+	h.tick++
+	h.looping = false
+	h.elapsed += interval
+	h.applyTickRateLocked()
+	h.mu.Unlock()
+
+	h.update(ctx, state)
+	if overrun && h.OnTickOverrun != nil {
+		h.OnTickOverrun(ctx, h.Stats(), duration)
+	}
+	return nil
+}
+
+// dueTimersLocked removes and returns the timers due on or before the tick, in the order they fire.
+func (h *MatchHarness) dueTimersLocked(tick int64) []*ScheduledTimer {
+	var due []*ScheduledTimer
+	h.timers = slices.DeleteFunc(h.timers, func(t *ScheduledTimer) bool {
+		if t.Tick <= tick {
+			due = append(due, t)
+			return true
+		}
+		return false
+	})
+	slices.SortStableFunc(due, func(a, b *ScheduledTimer) int {
+		return cmp.Compare(a.Tick, b.Tick)
+	})
+	return due
+}
+
+// applyTickRateLocked switches to the tick rate set through the dispatcher, if any.
+func (h *MatchHarness) applyTickRateLocked() {
+	if h.nextTickRate > 0 {
+		h.tickRate, h.nextTickRate = h.nextTickRate, 0
+	}
+}
+
+// Run runs the given number of ticks, stopping early without error if the match stops.
+func (h *MatchHarness) Run(ctx context.Context, ticks int) error {
+	for range ticks {
+		if h.Stopped() {
+			return nil
+		}
+		if err := h.Tick(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Advance runs as many ticks as fit in the duration at the match tick rate, which may change between ticks, stopping
+// early without error if the match stops.
+func (h *MatchHarness) Advance(ctx context.Context, d time.Duration) error {
+	end := h.Now().Add(d)
+	for !h.Stopped() {
+		h.mu.Lock()
+		tickRate := h.tickRate
+		if h.nextTickRate > 0 {
+			tickRate = h.nextTickRate
+		}
+		next := h.nowLocked().Add(time.Second / time.Duration(tickRate))
+		h.mu.Unlock()
+
+		if next.After(end) {
+			return nil
+		}
+		if err := h.Tick(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signal calls MatchSignal with the data and returns the match's response.
+func (h *MatchHarness) Signal(ctx context.Context, data string) (string, error) {
+	if err := h.running(); err != nil {
+		return "", err
+	}
+	state, result := h.match.MatchSignal(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, h.CurrentTick(), h.State(), data)
+	h.update(ctx, state)
+	return result, nil
+}
+
+// Terminate calls MatchTerminate with the grace period. The match keeps running ticks until the grace period has
+// passed, and stops immediately if it is 0.
+func (h *MatchHarness) Terminate(ctx context.Context, graceSeconds int) error {
+	if err := h.running(); err != nil {
+		return err
+	}
+	state := h.match.MatchTerminate(h.context(ctx), h.Logger, h.DB, h.NK, h.dispatcher, h.CurrentTick(), h.State(), graceSeconds)
+
+	h.mu.Lock()
+	h.terminateTick = h.tick + int64(graceSeconds*h.tickRate)
+	if graceSeconds <= 0 {
+		h.stopped = true
+	}
+	h.mu.Unlock()
+
+	h.update(ctx, state)
+	return nil
+}
+
+// Snapshot calls MatchSnapshot and returns a checkpoint of the match, or runtime.ErrMatchSnapshotUnsupported if the
+// match does not support runtime.MatchFeatureSnapshot.
+func (h *MatchHarness) Snapshot(ctx context.Context) (*MatchSnapshot, error) {
+	if err := h.running(); err != nil {
+		return nil, err
+	}
+	if !runtime.MatchSupports(h.match, runtime.MatchFeatureSnapshot) {
+		return nil, runtime.ErrMatchSnapshotUnsupported
+	}
+	snapshotter := h.match.(runtime.MatchSnapshotter)
+	data, err := snapshotter.MatchSnapshot(h.context(ctx), h.Logger, h.DB, h.NK, h.CurrentTick(), h.State())
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.applyTickRateLocked()
+	return &MatchSnapshot{
+		ID:              h.ID,
+		Tick:            h.tick,
+		TickRate:        h.tickRate,
+		Label:           h.label,
+		Presences:       slices.Clone(h.presences),
+		Acks:            maps.Clone(h.acks),
+		Timers:          h.cloneTimersLocked(),
+		ReconnectWindow: h.reconnect,
+		Reservations:    h.cloneReservationsLocked(),
+		Backfill:        cloneBackfill(h.backfill),
+		Data:            data,
+	}, nil
+}
+
+// Restore calls MatchRestore with the snapshot data and resumes the match from the snapshot, as the server does on
+// the node a match is restored to. Restore is called instead of Init. ErrMatchReconnectUnsupported is returned if the
+// snapshot has a reconnect window or reservations and the match does not support runtime.MatchFeatureReconnect.
+func (h *MatchHarness) Restore(ctx context.Context, snapshot *MatchSnapshot) error {
+	if !runtime.MatchSupports(h.match, runtime.MatchFeatureSnapshot) {
+		return runtime.ErrMatchSnapshotUnsupported
+	}
+	snapshotter := h.match.(runtime.MatchSnapshotter)
+	if snapshot.TickRate < matchTickRateMin || snapshot.TickRate > matchTickRateMax {
+		return runtime.ErrMatchTickRateInvalid
+	}
+	if !runtime.MatchSupports(h.match, runtime.MatchFeatureReconnect) && (snapshot.ReconnectWindow > 0 || len(snapshot.Reservations) > 0) {
+		return runtime.ErrMatchReconnectUnsupported
+	}
+
+	h.mu.Lock()
+	if h.initialized {
+		h.mu.Unlock()
+		return errMatchInitialized
+	}
+	h.ID, h.tick, h.tickRate, h.label = snapshot.ID, snapshot.Tick, snapshot.TickRate, snapshot.Label
+	h.elapsed = time.Duration(snapshot.Tick) * time.Second / time.Duration(snapshot.TickRate)
+	h.mu.Unlock()
+
+	state, err := snapshotter.MatchRestore(h.context(ctx), h.Logger, h.DB, h.NK, snapshot.Tick, snapshot.Data)
+	if err == nil && state == nil {
+		err = runtime.ErrMatchStateFailed
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		h.tick, h.tickRate, h.label, h.elapsed = 0, 0, "", 0
+		return err
+	}
+	h.state, h.presences, h.initialized = state, slices.Clone(snapshot.Presences), true
+	h.reconnect, h.backfill = snapshot.ReconnectWindow, cloneBackfill(snapshot.Backfill)
+	maps.Copy(h.acks, snapshot.Acks)
+	for _, r := range snapshot.Reservations {
+		h.reservations = append(h.reservations, &Reservation{Presence: r.Presence, Expires: r.Expires})
+	}
+	for _, t := range snapshot.Timers {
+		h.timers = append(h.timers, &ScheduledTimer{Key: t.Key, Data: slices.Clone(t.Data), Tick: t.Tick})
+	}
+	h.registerLocked()
+	return nil
+}
+
+/*
+Migrate moves the match to a new harness for the given match handler, as the server does when the node running it
+drains: the match is snapshotted and stopped without calling MatchTerminate, then restored in the new harness under
+the same ID, with its presences and any messages queued for later ticks. The new harness shares the logger, database,
+Nakama module, environment, version, module name, start time, deferred queue size and tick overrun function of this
+one, and runs on node.
+
+	restored, err := harness.Migrate(ctx, &Arena{}, "nakama2")
+*/
+func (h *MatchHarness) Migrate(ctx context.Context, match runtime.Match, node string) (*MatchHarness, error) {
+	snapshot, err := h.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := NewMatchHarness(match, h.NK)
+	migrated.Logger, migrated.DB, migrated.Env, migrated.Node = h.Logger, h.DB, h.Env, node
+	migrated.Version, migrated.Start, migrated.DeferredQueueSize = h.Version, h.Start, h.DeferredQueueSize
+	migrated.Module, migrated.OnTickOverrun = h.Module, h.OnTickOverrun
+	if err := migrated.Restore(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stopped = true
+	for tick, messages := range h.scripted {
+		if tick >= snapshot.Tick {
+			migrated.scripted[tick] = messages
+		}
+	}
+	h.scripted = make(map[int64][]*MatchData)
+	return migrated, nil
+}
+
+// Backfill returns the open slots the match set for the matchmaker to fill, or nil if it has none.
+func (h *MatchHarness) Backfill() *MatchBackfill {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return cloneBackfill(h.backfill)
+}
+
+/*
+FillBackfill runs the matchmaker against the open slots of the match, as the server does when tickets are queued while
+the match has slots open. Each entry whose properties match the backfill query, and whose own ticket query matches
+the string and numeric properties of the backfill, joins the match through Join while slots remain, with the entry in
+the context of its join callbacks under runtime.RUNTIME_CTX_MATCHMAKER_ENTRY, and each accepted join fills a slot. The
+ticket query is the Query of a *MatchmakerEntry, or else the query its ticket is queued with in NK. The entries that
+joined are returned.
+
+	joined, err := harness.FillBackfill(ctx, &runtimetest.MatchmakerEntry{
+		Presence:   carol,
+		Ticket:     ticket,
+		Properties: map[string]interface{}{"skill": 1200.0},
+	})
+*/
+func (h *MatchHarness) FillBackfill(ctx context.Context, entries ...runtime.MatchmakerEntry) ([]runtime.MatchmakerEntry, error) {
+	joined := make([]runtime.MatchmakerEntry, 0, len(entries))
+	for _, entry := range entries {
+		h.mu.Lock()
+		backfill := cloneBackfill(h.backfill)
+		h.mu.Unlock()
+
+		if backfill == nil {
+			break
+		}
+		query, err := runtime.ParseMatchmakerQuery(backfill.Query)
+		if err != nil {
+			return joined, err
+		}
+		if !query.Matches(entry) {
+			continue
+		}
+		ticketQuery, err := runtime.ParseMatchmakerQuery(h.ticketQuery(entry))
+		if err != nil {
+			return joined, err
+		}
+		if !ticketQuery.Evaluate(backfillProperties(backfill)).Matched {
+			continue
+		}
+		accepted, _, err := h.Join(context.WithValue(ctx, runtime.RUNTIME_CTX_MATCHMAKER_ENTRY, entry), entry.GetPresence(), nil)
+		if err != nil {
+			return joined, err
+		}
+		if !accepted {
+			continue
+		}
+		joined = append(joined, entry)
+
+		h.mu.Lock()
+		if h.backfill != nil {
+			if h.backfill.Slots--; h.backfill.Slots <= 0 {
+				h.backfill = nil
+			}
+		}
+		h.mu.Unlock()
+	}
+	return joined, nil
+}
+
+// ticketQuery returns the query the entry matches backfills with: the Query of a *MatchmakerEntry if it sets one,
+// otherwise the query of its ticket at its current stage if the ticket is queued in NK, and "*" if neither is known.
+func (h *MatchHarness) ticketQuery(entry runtime.MatchmakerEntry) string {
+	if e, ok := entry.(*MatchmakerEntry); ok && e.Query != "" {
+		return e.Query
+	}
+	if nk, ok := h.NK.(*NakamaModule); ok {
+		nk.mu.Lock()
+		defer nk.mu.Unlock()
+
+		for _, ticket := range nk.tickets {
+			if ticket.Ticket == entry.GetTicket() {
+				_, query := ticket.StageAt(nk.Now())
+				return query
+			}
+		}
+	}
+	return "*"
+}
+
+// backfillProperties returns the properties of the backfill as the properties a ticket query is evaluated against.
+func backfillProperties(backfill *MatchBackfill) map[string]interface{} {
+	properties := make(map[string]interface{}, len(backfill.StringProperties)+len(backfill.NumericProperties))
+	for k, v := range backfill.StringProperties {
+		properties[k] = v
+	}
+	for k, v := range backfill.NumericProperties {
+		properties[k] = v
+	}
+	return properties
+}
+
+func cloneBackfill(backfill *MatchBackfill) *MatchBackfill {
+	if backfill == nil {
+		return nil
+	}
+	return &MatchBackfill{
+		Slots:             backfill.Slots,
+		Query:             backfill.Query,
+		StringProperties:  maps.Clone(backfill.StringProperties),
+		NumericProperties: maps.Clone(backfill.NumericProperties),
+	}
+}
+
+// Ack returns the sequence number of the last message from the presence delivered to MatchLoop, which the server
+// sends to the presence as the ack on the match data it receives, or 0 if none was.
+func (h *MatchHarness) Ack(presence runtime.Presence) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.acks[presence.GetSessionId()]
+}
+
+// Dispatcher returns the dispatcher the harness passes to the match.
+func (h *MatchHarness) Dispatcher() runtime.MatchDispatcher {
+	return h.dispatcher
+}
+
+// State returns the current match state.
+func (h *MatchHarness) State() interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.state
+}
+
+// CurrentTick returns the tick the next call into the match receives.
+func (h *MatchHarness) CurrentTick() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.tick
+}
+
+// nextTick returns the first tick whose messages have not been handed to MatchLoop yet.
+func (h *MatchHarness) nextTick() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.looping {
+		return h.tick + 1
+	}
+	return h.tick
+}
+
+// TickRate returns the current match tick rate. A tick rate set through the dispatcher is returned once the tick it
+// was set in has ended.
+func (h *MatchHarness) TickRate() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.tickRate
+}
+
+// Label returns the current match label.
+func (h *MatchHarness) Label() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.label
+}
+
+// Now returns the time seen by the match at the current tick.
+func (h *MatchHarness) Now() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.nowLocked()
+}
+
+func (h *MatchHarness) nowLocked() time.Time {
+	return h.Start.Add(h.elapsed)
+}
+
+// Result returns the result the match recorded through MatchDispatcher.MatchEnd, or nil if it has not ended that
+// way. Pass it to Initializer.MatchEnd to run the function a module registered with RegisterMatchEnd.
+func (h *MatchHarness) Result() *runtime.MatchResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.result
+}
+
+// recordBroadcastsLocked records delivered broadcasts, counting them in the stats of the match, which unlike the
+// recorded broadcasts are not reset by ClearRecorded.
+func (h *MatchHarness) recordBroadcastsLocked(broadcasts ...*Broadcast) {
+	for _, broadcast := range broadcasts {
+		h.sent++
+		h.broadcastBytes += int64(len(broadcast.Data) * len(broadcast.Presences))
+	}
+	h.broadcasts = append(h.broadcasts, broadcasts...)
+}
+
+// Stats returns the runtime metrics of the match. Tick durations are measured in real time, from the start of the
+// timers due on a tick to the return of MatchLoop.
+func (h *MatchHarness) Stats() *runtime.MatchStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := &runtime.MatchStats{
+		MatchID:          h.ID,
+		Module:           h.Module,
+		Matches:          1,
+		Ticks:            h.ticks,
+		TickDurationMax:  h.tickMax,
+		TickOverruns:     h.overruns,
+		MessagesReceived: h.received,
+		MessagesSent:     h.sent,
+		BroadcastBytes:   h.broadcastBytes,
+	}
+	if h.ticks > 0 {
+		stats.TickDurationAvg = h.tickTotal / time.Duration(h.ticks)
+	}
+	for _, presence := range h.presences {
+		if spectating(presence) {
+			stats.Spectators++
+		} else {
+			stats.Presences++
+		}
+	}
+	return stats
+}
+
+// Stopped returns true once the match has stopped.
+func (h *MatchHarness) Stopped() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.stopped
+}
+
+// Presences returns the presences in the match in the order they joined.
+func (h *MatchHarness) Presences() []runtime.Presence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Clone(h.presences)
+}
+
+// Timers returns the timers scheduled through the dispatcher that have not fired or been cancelled, in the order they
+// were scheduled.
+func (h *MatchHarness) Timers() []*ScheduledTimer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.cloneTimersLocked()
+}
+
+func (h *MatchHarness) cloneTimersLocked() []*ScheduledTimer {
+	timers := make([]*ScheduledTimer, 0, len(h.timers))
+	for _, t := range h.timers {
+		timers = append(timers, &ScheduledTimer{Key: t.Key, Data: slices.Clone(t.Data), Tick: t.Tick})
+	}
+	return timers
+}
+
+// Reservations returns the slots reserved for disconnected presences to rejoin, in the order they were reserved.
+func (h *MatchHarness) Reservations() []*Reservation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.cloneReservationsLocked()
+}
+
+func (h *MatchHarness) cloneReservationsLocked() []*Reservation {
+	reservations := make([]*Reservation, 0, len(h.reservations))
+	for _, r := range h.reservations {
+		reservations = append(reservations, &Reservation{Presence: r.Presence, Expires: r.Expires})
+	}
+	return reservations
+}
+
+// Broadcasts returns the messages the match has sent in the order they were delivered. Deferred broadcasts are
+// delivered at the end of the tick they were sent in.
+func (h *MatchHarness) Broadcasts() []*Broadcast {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Clone(h.broadcasts)
+}
+
+// Kicks returns the presences the match has kicked.
+func (h *MatchHarness) Kicks() []runtime.Presence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Clone(h.kicks)
+}
+
+// LabelUpdates returns the labels the match has set through the dispatcher.
+func (h *MatchHarness) LabelUpdates() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Clone(h.labelUpdates)
+}
+
+// ClearRecorded discards the recorded broadcasts, kicks and label updates.
+func (h *MatchHarness) ClearRecorded() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.broadcasts, h.kicks, h.labelUpdates = nil, nil, nil
+}
+
+func samePresence(presence runtime.Presence) func(runtime.Presence) bool {
+	return func(p runtime.Presence) bool {
+		return p.GetSessionId() == presence.GetSessionId() && p.GetNodeId() == presence.GetNodeId()
+	}
+}
+
+// spectatorBroadcast is a message sent through BroadcastSpectators, held until the due tick.
+type spectatorBroadcast struct {
+	due       int64
+	broadcast *Broadcast
+}
+
+type matchDispatcher struct {
+	h *MatchHarness
+}
+
+// recipientsLocked returns the presences in the match a message addressed to presences is delivered to.
+func (d *matchDispatcher) recipientsLocked(presences []runtime.Presence) []runtime.Presence {
+	if len(presences) == 0 {
+		return slices.DeleteFunc(slices.Clone(d.h.presences), spectating)
+	}
+	recipients := make([]runtime.Presence, 0, len(presences))
+	for _, presence := range presences {
+		if i := slices.IndexFunc(d.h.presences, samePresence(presence)); i >= 0 {
+			recipients = append(recipients, d.h.presences[i])
+		}
+	}
+	return recipients
+}
+
+func (d *matchDispatcher) broadcastLocked(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable, deferred bool) *Broadcast {
+	if d.h.stopped {
+		return nil
+	}
+	recipients := d.recipientsLocked(presences)
+	if len(recipients) == 0 {
+		return nil
+	}
+	return &Broadcast{
+		Tick:      d.h.tick,
+		OpCode:    opCode,
+		Data:      slices.Clone(data),
+		Presences: recipients,
+		Sender:    sender,
+		Reliable:  reliable,
+		Deferred:  deferred,
+	}
+}
+
+func (d *matchDispatcher) BroadcastMessage(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable bool) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if broadcast := d.broadcastLocked(opCode, data, presences, sender, reliable, false); broadcast != nil {
+		d.h.recordBroadcastsLocked(broadcast)
+	}
+	return nil
+}
+
+func (d *matchDispatcher) BroadcastMessageDeferred(opCode int64, data []byte, presences []runtime.Presence, sender runtime.Presence, reliable bool) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if len(d.h.deferred) >= d.h.DeferredQueueSize {
+		return runtime.ErrDeferredBroadcastFull
+	}
+	if broadcast := d.broadcastLocked(opCode, data, presences, sender, reliable, true); broadcast != nil {
+		d.h.deferred = append(d.h.deferred, broadcast)
+	}
+	return nil
+}
+
+func (d *matchDispatcher) BroadcastMessages(messages []*runtime.MatchMessage) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	for _, message := range messages {
+		if broadcast := d.broadcastLocked(message.OpCode, message.Data, message.Presences, message.Sender, message.Reliable, false); broadcast != nil {
+			d.h.recordBroadcastsLocked(broadcast)
+		}
+	}
+	return nil
+}
+
+func (d *matchDispatcher) BroadcastMessagesDeferred(messages []*runtime.MatchMessage) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if len(d.h.deferred)+len(messages) > d.h.DeferredQueueSize {
+		return runtime.ErrDeferredBroadcastFull
+	}
+	for _, message := range messages {
+		if broadcast := d.broadcastLocked(message.OpCode, message.Data, message.Presences, message.Sender, message.Reliable, true); broadcast != nil {
+			d.h.deferred = append(d.h.deferred, broadcast)
+		}
+	}
+	return nil
+}
+
+// spectatorBroadcastLocked returns the broadcast delivered to the spectators in the match, if there are any.
+func (d *matchDispatcher) spectatorBroadcastLocked(broadcast *Broadcast) *Broadcast {
+	if d.h.stopped {
+		return nil
+	}
+	spectators := slices.DeleteFunc(slices.Clone(d.h.presences), func(p runtime.Presence) bool { return !spectating(p) })
+	if len(spectators) == 0 {
+		return nil
+	}
+	b := *broadcast
+	b.Presences = spectators
+	return &b
+}
+
+func (d *matchDispatcher) BroadcastSpectators(opCode int64, data []byte, sender runtime.Presence, reliable bool) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	broadcast := &Broadcast{
+		Tick:       d.h.tick,
+		OpCode:     opCode,
+		Data:       slices.Clone(data),
+		Sender:     sender,
+		Reliable:   reliable,
+		Spectators: true,
+	}
+	if d.h.spectatorDelay > 0 {
+		d.h.spectated = append(d.h.spectated, &spectatorBroadcast{due: d.h.tick + d.h.spectatorDelay, broadcast: broadcast})
+	} else if broadcast = d.spectatorBroadcastLocked(broadcast); broadcast != nil {
+		d.h.recordBroadcastsLocked(broadcast)
+	}
+	return nil
+}
+
+func (d *matchDispatcher) SetSpectatorDelay(ticks int64) error {
+	if ticks < 0 {
+		return runtime.NewError("invalid spectator delay", 3)
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	d.h.spectatorDelay = ticks
+	return nil
+}
+
+func (d *matchDispatcher) MatchKick(presences []runtime.Presence) error {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if d.h.stopped {
+		return nil
+	}
+	kicked := d.h.removePresencesLocked(presences)
+	d.h.kicks = append(d.h.kicks, kicked...)
+	d.h.kickPending = append(d.h.kickPending, kicked...)
+	return nil
+}
+
+func (d *matchDispatcher) MatchEnd(result *runtime.MatchResult) error {
+	if result == nil {
+		return runtime.ErrMatchResultInvalid
+	}
+	for _, presence := range result.Presences {
+		if presence == nil || presence.UserID == "" {
+			return runtime.ErrMatchResultInvalid
+		}
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if d.h.stopped || d.h.result != nil {
+		return runtime.ErrMatchEnded
+	}
+	recorded := *result
+	recorded.MatchID, recorded.Tick, recorded.EndTime = d.h.ID, d.h.tick, d.h.nowLocked().Unix()
+	d.h.result = &recorded
+	if nk, ok := d.h.NK.(*NakamaModule); ok {
+		nk.AddMatchResult(&recorded)
+	}
+	return nil
+}
+
+func (d *matchDispatcher) SetMatchmakerBackfill(slots int, query string, stringProperties map[string]string, numericProperties map[string]float64) error {
+	if slots < 0 {
+		return runtime.ErrMatchBackfillInvalid
+	}
+	if query == "" {
+		query = "*"
+	}
+	if _, err := runtime.ParseMatchmakerQuery(query); err != nil {
+		return err
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	if slots == 0 {
+		d.h.backfill = nil
+		return nil
+	}
+	d.h.backfill = cloneBackfill(&MatchBackfill{
+		Slots:             slots,
+		Query:             query,
+		StringProperties:  stringProperties,
+		NumericProperties: numericProperties,
+	})
+	return nil
+}
+
+func (d *matchDispatcher) SetTickRate(rate int) error {
+	if rate < matchTickRateMin || rate > matchTickRateMax {
+		return runtime.ErrMatchTickRateInvalid
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	d.h.nextTickRate = rate
+	return nil
+}
+
+func (d *matchDispatcher) ScheduleAfter(ticks int64, key string, data []byte) error {
+	if !runtime.MatchSupports(d.h.match, runtime.MatchFeatureTimer) {
+		return runtime.ErrMatchTimerUnsupported
+	}
+	if key == "" || ticks < 1 {
+		return runtime.ErrMatchTimerInvalid
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	d.h.timers = slices.DeleteFunc(d.h.timers, func(t *ScheduledTimer) bool { return t.Key == key })
+	d.h.timers = append(d.h.timers, &ScheduledTimer{Key: key, Data: slices.Clone(data), Tick: d.h.tick + ticks})
+	return nil
+}
+
+func (d *matchDispatcher) ScheduleAfterDuration(after time.Duration, key string, data []byte) error {
+	if after <= 0 {
+		return runtime.ErrMatchTimerInvalid
+	}
+	rate := time.Duration(d.h.TickRate())
+	// The whole seconds and the remainder are converted separately so that long durations do not overflow.
+	ticks := int64(after/time.Second*rate + (after%time.Second*rate+time.Second-1)/time.Second)
+	return d.ScheduleAfter(ticks, key, data)
+}
+
+func (d *matchDispatcher) CancelScheduled(key string) bool {
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	n := len(d.h.timers)
+	d.h.timers = slices.DeleteFunc(d.h.timers, func(t *ScheduledTimer) bool { return t.Key == key })
+	return len(d.h.timers) < n
+}
+
+func (d *matchDispatcher) SetReconnectWindow(window time.Duration) error {
+	if !runtime.MatchSupports(d.h.match, runtime.MatchFeatureReconnect) {
+		return runtime.ErrMatchReconnectUnsupported
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	d.h.reconnect = max(window, 0)
+	return nil
+}
+
+func (d *matchDispatcher) MatchLabelUpdate(label string) error {
+	if len(label) > matchLabelMaxBytes {
+		return runtime.ErrMatchLabelTooLong
+	}
+
+	d.h.mu.Lock()
+	defer d.h.mu.Unlock()
+
+	d.h.label = label
+	d.h.labelUpdates = append(d.h.labelUpdates, label)
+	return nil
+}
@@ -0,0 +1,918 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	opCodeChat = 1
+	opCodeKick = 2
+)
+
+type testMatchState struct {
+	joined   int
+	left     int
+	messages int
+}
+
+// testMatch echoes chat messages as deferred broadcasts, kicks on request and ends after 2 seconds without players.
+type testMatch struct{}
+
+func (m *testMatch) MatchInit(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, params map[string]interface{}) (interface{}, int, string) {
+	label, _ := params["label"].(string)
+	return &testMatchState{}, 10, label
+}
+
+func (m *testMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence, metadata map[string]string) (interface{}, bool, string) {
+	if metadata["banned"] == "true" {
+		return state, false, "banned"
+	}
+	return state, true, ""
+}
+
+func (m *testMatch) MatchJoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	state.(*testMatchState).joined += len(presences)
+	_ = dispatcher.MatchLabelUpdate("joined")
+	return state
+}
+
+func (m *testMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	state.(*testMatchState).left += len(presences)
+	return state
+}
+
+func (m *testMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
+	s := state.(*testMatchState)
+	for _, message := range messages {
+		s.messages++
+		switch message.GetOpCode() {
+		case opCodeChat:
+			if err := dispatcher.BroadcastMessageDeferred(opCodeChat, message.GetData(), nil, message, true); err != nil {
+				_ = dispatcher.BroadcastMessage(opCodeChat, []byte(err.Error()), []runtime.Presence{message}, nil, true)
+			}
+		case opCodeKick:
+			_ = dispatcher.MatchKick([]runtime.Presence{message})
+		}
+	}
+	if s.joined == s.left && tick >= 20 {
+		return nil
+	}
+	return s
+}
+
+func (m *testMatch) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, graceSeconds int) interface{} {
+	return state
+}
+
+func (m *testMatch) MatchSignal(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, data string) (interface{}, string) {
+	tickRate, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_TICK_RATE).(int)
+	label, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_LABEL).(string)
+	return state, strings.Join([]string{data, label, strconv.Itoa(tickRate)}, " ")
+}
+
+func TestMatchHarness(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, map[string]interface{}{"label": "open"}); err != nil {
+		t.Fatal(err)
+	}
+
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	if accepted, reason, err := harness.Join(ctx, bob, map[string]string{"banned": "true"}); accepted || reason != "banned" || err != nil {
+		t.Fatalf("expected bob to be rejected, got %v %q %v", accepted, reason, err)
+	}
+	if accepted, _, err := harness.Join(ctx, alice, nil); !accepted || err != nil {
+		t.Fatalf("expected alice to join, got %v %v", accepted, err)
+	}
+	if result, _ := harness.Signal(ctx, "ping"); result != "ping joined 10" {
+		t.Fatalf("unexpected signal result %q", result)
+	}
+
+	harness.Send(alice, opCodeChat, []byte("hello"), true)
+	harness.Send(bob, opCodeChat, []byte("ignored"), true)
+	harness.SendAt(5, alice, opCodeKick, nil, true)
+	if err := harness.Advance(ctx, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if harness.CurrentTick() != 10 || !harness.Now().Equal(harness.Start.Add(time.Second)) {
+		t.Fatalf("expected 10 ticks in a second, got tick %v at %v", harness.CurrentTick(), harness.Now())
+	}
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 1 || string(broadcasts[0].Data) != "hello" || !broadcasts[0].Deferred || broadcasts[0].Tick != 0 {
+		t.Fatalf("expected a single deferred chat broadcast, got %v", broadcasts)
+	}
+	if kicks := harness.Kicks(); len(kicks) != 1 || len(harness.Presences()) != 0 || harness.State().(*testMatchState).left != 1 {
+		t.Fatalf("expected alice to be kicked and leave, got kicks %v", kicks)
+	}
+	if labels := harness.LabelUpdates(); len(labels) != 1 || harness.Label() != "joined" {
+		t.Fatalf("unexpected label updates %v", labels)
+	}
+
+	if err := harness.Run(ctx, 100); err != nil {
+		t.Fatal(err)
+	}
+	if !harness.Stopped() || harness.CurrentTick() != 21 {
+		t.Fatalf("expected match to end on tick 20, stopped %v at tick %v", harness.Stopped(), harness.CurrentTick())
+	}
+	if err := harness.Tick(ctx); !errors.Is(err, runtime.ErrMatchNotFound) {
+		t.Fatalf("expected stopped match to be not found, got %v", err)
+	}
+}
+
+func TestMatchHarnessLimits(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, map[string]interface{}{"label": strings.Repeat("x", 2049)}); !errors.Is(err, runtime.ErrMatchLabelTooLong) {
+		t.Fatalf("expected label too long error, got %v", err)
+	}
+
+	harness = NewMatchHarness(&testMatch{}, NewNakamaModule())
+	harness.DeferredQueueSize = 1
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice := NewPresence(aliceID, "alice")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	harness.Send(alice, opCodeChat, []byte("first"), true)
+	harness.Send(alice, opCodeChat, []byte("second"), true)
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 2 || string(broadcasts[0].Data) != runtime.ErrDeferredBroadcastFull.Error() || string(broadcasts[1].Data) != "first" {
+		t.Fatalf("expected second deferred broadcast to be rejected, got %v", broadcasts)
+	}
+	if err := harness.Dispatcher().MatchLabelUpdate(strings.Repeat("x", 2049)); !errors.Is(err, runtime.ErrMatchLabelTooLong) {
+		t.Fatalf("expected label too long error, got %v", err)
+	}
+
+	if err := harness.Terminate(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Run(ctx, 20); err != nil || !harness.Stopped() || harness.CurrentTick() != 11 {
+		t.Fatalf("expected match to stop after the grace period, stopped %v at tick %v, %v", harness.Stopped(), harness.CurrentTick(), err)
+	}
+}
+
+// testSnapshotMatch is a testMatch that can be snapshotted and restored.
+type testSnapshotMatch struct {
+	testMatch
+}
+
+func (m *testSnapshotMatch) MatchSnapshot(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tick int64, state interface{}) ([]byte, error) {
+	s := state.(*testMatchState)
+	return fmt.Appendf(nil, "%d %d %d", s.joined, s.left, s.messages), nil
+}
+
+func (m *testSnapshotMatch) MatchRestore(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tick int64, data []byte) (interface{}, error) {
+	s := &testMatchState{}
+	if _, err := fmt.Sscanf(string(data), "%d %d %d", &s.joined, &s.left, &s.messages); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func TestMatchHarnessMigrate(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testSnapshotMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice := NewPresence(aliceID, "alice")
+	if accepted, _, err := harness.Join(ctx, alice, nil); !accepted || err != nil {
+		t.Fatalf("expected alice to join, got %v %v", accepted, err)
+	}
+	harness.Send(alice, opCodeChat, []byte("before"), true)
+	harness.SendAt(7, alice, opCodeChat, []byte("after"), true)
+	if err := harness.Run(ctx, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := harness.Migrate(ctx, &testSnapshotMatch{}, "nakama2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !harness.Stopped() || migrated.Stopped() {
+		t.Fatal("expected the match to move to the new harness")
+	}
+	if migrated.ID != harness.ID || migrated.CurrentTick() != 5 || migrated.TickRate() != 10 || migrated.Label() != "joined" || len(migrated.Presences()) != 1 {
+		t.Fatalf("unexpected migrated match %v tick %v rate %v label %q", migrated.ID, migrated.CurrentTick(), migrated.TickRate(), migrated.Label())
+	}
+	if result, _ := migrated.Signal(ctx, "ping"); result != "ping joined 10" {
+		t.Fatalf("unexpected signal result %q", result)
+	}
+	if err := migrated.Run(ctx, 5); err != nil {
+		t.Fatal(err)
+	}
+	if state := migrated.State().(*testMatchState); state.joined != 1 || state.messages != 2 {
+		t.Fatalf("unexpected migrated state %+v", state)
+	}
+
+	unsupported := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := unsupported.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unsupported.Snapshot(ctx); !errors.Is(err, runtime.ErrMatchSnapshotUnsupported) {
+		t.Fatalf("expected snapshots to be unsupported, got %v", err)
+	}
+}
+
+func TestMatchHarnessBatch(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	harness.DeferredQueueSize = 2
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	_, _, _ = harness.Join(ctx, bob, nil)
+
+	dispatcher := harness.Dispatcher()
+	if err := dispatcher.BroadcastMessages([]*runtime.MatchMessage{
+		{OpCode: 1, Data: []byte("a"), Presences: []runtime.Presence{alice}},
+		{OpCode: 1, Data: []byte("b"), Presences: []runtime.Presence{bob}, Reliable: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	deferred := []*runtime.MatchMessage{{OpCode: 2, Data: []byte("c")}, {OpCode: 2, Data: []byte("d")}, {OpCode: 2, Data: []byte("e")}}
+	if err := dispatcher.BroadcastMessagesDeferred(deferred); !errors.Is(err, runtime.ErrDeferredBroadcastFull) {
+		t.Fatalf("expected batch over the deferred limit to be rejected, got %v", err)
+	}
+	if err := dispatcher.BroadcastMessagesDeferred(deferred[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 4 {
+		t.Fatalf("expected 4 broadcasts, got %v", broadcasts)
+	}
+	if broadcasts[0].Presences[0] != alice || broadcasts[1].Presences[0] != bob || !broadcasts[1].Reliable {
+		t.Fatalf("expected each message to go to its own presences, got %v %v", broadcasts[0], broadcasts[1])
+	}
+	if !broadcasts[2].Deferred || len(broadcasts[3].Presences) != 2 || string(broadcasts[3].Data) != "d" {
+		t.Fatalf("expected deferred messages to go to every presence, got %v %v", broadcasts[2], broadcasts[3])
+	}
+}
+
+func TestMatchHarnessSetTickRate(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = harness.Join(ctx, NewPresence(aliceID, "alice"), nil)
+
+	dispatcher := harness.Dispatcher()
+	if err := dispatcher.SetTickRate(61); !errors.Is(err, runtime.ErrMatchTickRateInvalid) {
+		t.Fatalf("expected invalid tick rate error, got %v", err)
+	}
+	if err := harness.Advance(ctx, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.SetTickRate(20); err != nil {
+		t.Fatal(err)
+	}
+	if harness.TickRate() != 10 {
+		t.Fatalf("expected tick rate to change on the next tick, got %v", harness.TickRate())
+	}
+	if err := harness.Advance(ctx, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if harness.CurrentTick() != 30 || !harness.Now().Equal(harness.Start.Add(2*time.Second)) {
+		t.Fatalf("expected 20 ticks in the second second, got tick %v at %v", harness.CurrentTick(), harness.Now())
+	}
+	if result, _ := harness.Signal(ctx, "ping"); result != "ping joined 20" {
+		t.Fatalf("expected the new tick rate in the context, got %q", result)
+	}
+}
+
+// timerMatch records the timers it receives, and reschedules the "round" timer every 3 ticks.
+type timerMatch struct {
+	testMatch
+	fired []string
+}
+
+func (m *timerMatch) MatchTimer(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, key string, data []byte) interface{} {
+	m.fired = append(m.fired, fmt.Sprintf("%v:%v:%s", tick, key, data))
+	if key == "round" {
+		_ = dispatcher.ScheduleAfter(3, "round", data)
+	}
+	return state
+}
+
+func TestMatchHarnessTimers(t *testing.T) {
+	ctx := context.Background()
+	match := &timerMatch{}
+	harness := NewMatchHarness(match, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = harness.Join(ctx, NewPresence(aliceID, "alice"), nil)
+
+	dispatcher := harness.Dispatcher()
+	if err := dispatcher.ScheduleAfter(0, "now", nil); !errors.Is(err, runtime.ErrMatchTimerInvalid) {
+		t.Fatalf("expected invalid timer error, got %v", err)
+	}
+	for _, timer := range []struct {
+		ticks int64
+		key   string
+	}{{3, "round"}, {2, "vote"}, {2, "respawn"}, {5, "cancelled"}} {
+		if err := dispatcher.ScheduleAfter(timer.ticks, timer.key, []byte(timer.key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !dispatcher.CancelScheduled("cancelled") || dispatcher.CancelScheduled("cancelled") {
+		t.Fatal("expected the timer to be cancelled once")
+	}
+	if err := harness.Run(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"2:vote:vote", "2:respawn:respawn", "3:round:round", "6:round:round"}
+	if !slices.Equal(match.fired, expected) {
+		t.Fatalf("expected timers %v, got %v", expected, match.fired)
+	}
+	if timers := harness.Timers(); len(timers) != 1 || timers[0].Key != "round" || timers[0].Tick != 9 {
+		t.Fatalf("unexpected scheduled timers %v", timers)
+	}
+
+	unsupported := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := unsupported.Dispatcher().ScheduleAfter(1, "round", nil); !errors.Is(err, runtime.ErrMatchTimerUnsupported) {
+		t.Fatalf("expected timers to be unsupported, got %v", err)
+	}
+}
+
+func TestMatchHarnessTimerDurations(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&timerMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	dispatcher := harness.Dispatcher()
+	if err := dispatcher.ScheduleAfterDuration(0, "now", nil); !errors.Is(err, runtime.ErrMatchTimerInvalid) {
+		t.Fatalf("expected invalid timer error, got %v", err)
+	}
+	for _, timer := range []struct {
+		after time.Duration
+		key   string
+	}{{time.Nanosecond, "soon"}, {250 * time.Millisecond, "round"}, {time.Second, "vote"}, {time.Hour, "season"}} {
+		if err := dispatcher.ScheduleAfterDuration(timer.after, timer.key, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dispatcher.SetTickRate(20); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.ScheduleAfterDuration(250*time.Millisecond, "fast", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ticks := make(map[string]int64)
+	for _, timer := range harness.Timers() {
+		ticks[timer.Key] = timer.Tick
+	}
+	expected := map[string]int64{"soon": 1, "round": 3, "vote": 10, "season": 36000, "fast": 6}
+	if !maps.Equal(ticks, expected) {
+		t.Fatalf("expected timer ticks %v, got %v", expected, ticks)
+	}
+
+	unsupported := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := unsupported.Dispatcher().ScheduleAfterDuration(time.Second, "round", nil); !errors.Is(err, runtime.ErrMatchTimerUnsupported) {
+		t.Fatalf("expected timers to be unsupported, got %v", err)
+	}
+}
+
+// reconnectMatch records rejoins and expired reservations.
+type reconnectMatch struct {
+	testMatch
+	events []string
+}
+
+func (m *reconnectMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	for _, presence := range presences {
+		m.events = append(m.events, fmt.Sprintf("%v:leave:%v:%v", tick, presence.GetUsername(), presence.GetReason()))
+	}
+	return state
+}
+
+func (m *reconnectMatch) MatchRejoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence) interface{} {
+	m.events = append(m.events, fmt.Sprintf("%v:rejoin:%v", tick, presence.GetUsername()))
+	return state
+}
+
+func (m *reconnectMatch) MatchReservationExpired(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence) interface{} {
+	m.events = append(m.events, fmt.Sprintf("%v:expired:%v", tick, presence.GetUsername()))
+	return state
+}
+
+func TestMatchHarnessReconnect(t *testing.T) {
+	ctx := context.Background()
+	match := &reconnectMatch{}
+	harness := NewMatchHarness(match, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Dispatcher().SetReconnectWindow(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	_, _, _ = harness.Join(ctx, bob, nil)
+
+	if err := harness.Disconnect(ctx, alice, bob); err != nil {
+		t.Fatal(err)
+	}
+	if reservations := harness.Reservations(); len(reservations) != 2 || !reservations[0].Expires.Equal(harness.Start.Add(time.Second)) {
+		t.Fatalf("unexpected reservations %v", reservations)
+	}
+	if err := harness.Run(ctx, 5); err != nil {
+		t.Fatal(err)
+	}
+	// Alice reconnects with a new session, which is admitted even though the match would reject it.
+	if rejoined, _, err := harness.Join(ctx, NewPresence(aliceID, "alice"), map[string]string{"banned": "true"}); !rejoined || err != nil {
+		t.Fatalf("expected alice to rejoin, got %v %v", rejoined, err)
+	}
+	if err := harness.Run(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"0:leave:alice:4", "0:leave:bob:4", "5:rejoin:alice", "10:expired:bob"}
+	if !slices.Equal(match.events, expected) {
+		t.Fatalf("expected events %v, got %v", expected, match.events)
+	}
+	if len(harness.Presences()) != 1 || len(harness.Reservations()) != 0 {
+		t.Fatalf("unexpected presences %v and reservations %v", harness.Presences(), harness.Reservations())
+	}
+
+	unsupported := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := unsupported.Dispatcher().SetReconnectWindow(time.Second); !errors.Is(err, runtime.ErrMatchReconnectUnsupported) {
+		t.Fatalf("expected reconnection to be unsupported, got %v", err)
+	}
+}
+
+// snapshotReconnectMatch is a testSnapshotMatch that holds the slots of presences that disconnect.
+type snapshotReconnectMatch struct {
+	testSnapshotMatch
+}
+
+func (m *snapshotReconnectMatch) MatchRejoin(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence) interface{} {
+	return state
+}
+
+func (m *snapshotReconnectMatch) MatchReservationExpired(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence) interface{} {
+	return state
+}
+
+func TestMatchHarnessMigrateReservations(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&snapshotReconnectMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Dispatcher().SetReconnectWindow(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	alice := NewPresence(aliceID, "alice")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	if err := harness.Disconnect(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := harness.Migrate(ctx, &testSnapshotMatch{}, "nakama2"); !errors.Is(err, runtime.ErrMatchReconnectUnsupported) {
+		t.Fatalf("expected a handler without reconnection to be rejected, got %v", err)
+	}
+	if harness.Stopped() {
+		t.Fatal("expected the match to keep running after a failed migration")
+	}
+	migrated, err := harness.Migrate(ctx, &snapshotReconnectMatch{}, "nakama2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rejoined, _, err := migrated.Join(ctx, NewPresence(aliceID, "alice"), nil); !rejoined || err != nil {
+		t.Fatalf("expected alice to rejoin the migrated match, got %v %v", rejoined, err)
+	}
+}
+
+func TestMatchHarnessSpectators(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := NewPresence(aliceID, "alice"), NewSpectator(bobID, "bob")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	_, _, _ = harness.Join(ctx, bob, nil)
+
+	dispatcher := harness.Dispatcher()
+	var runtimeErr *runtime.Error
+	if err := dispatcher.SetSpectatorDelay(-1); !errors.As(err, &runtimeErr) || runtimeErr.Code != 3 {
+		t.Fatalf("expected an invalid spectator delay error, got %v", err)
+	}
+	if err := dispatcher.SetSpectatorDelay(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.BroadcastSpectators(3, []byte("replay"), nil, true); err != nil {
+		t.Fatal(err)
+	}
+	harness.Send(alice, opCodeChat, []byte("alice"), true)
+	harness.Send(bob, opCodeChat, []byte("bob"), true)
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 1 || string(broadcasts[0].Data) != "alice" || len(broadcasts[0].Presences) != 1 || broadcasts[0].Presences[0] != alice {
+		t.Fatalf("expected only alice's message to be echoed, only to alice, got %v", broadcasts)
+	}
+
+	if err := harness.Run(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts = harness.Broadcasts()
+	if len(broadcasts) != 2 || !broadcasts[1].Spectators || broadcasts[1].Tick != 0 || len(broadcasts[1].Presences) != 1 || broadcasts[1].Presences[0] != bob {
+		t.Fatalf("expected the spectator broadcast to reach bob after 2 ticks, got %v", broadcasts)
+	}
+}
+
+func TestMatchHarnessAcks(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	_, _, _ = harness.Join(ctx, bob, nil)
+
+	harness.SendData(0, &MatchData{Presence: *alice, OpCode: opCodeChat, Data: []byte("b"), Sequence: 3})
+	harness.SendData(0, &MatchData{Presence: *alice, OpCode: opCodeChat, Data: []byte("a"), Sequence: 2})
+	harness.Send(bob, opCodeChat, []byte("c"), true)
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ack := harness.Ack(alice); ack != 3 {
+		t.Fatalf("expected alice's ack to be 3, got %v", ack)
+	}
+	if ack := harness.Ack(bob); ack != 0 {
+		t.Fatalf("expected no ack for bob, got %v", ack)
+	}
+
+	harness.SendData(1, &MatchData{Presence: *alice, OpCode: opCodeChat, Data: []byte("d"), Sequence: 4})
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 4 || broadcasts[0].Tick != 0 || broadcasts[3].Tick != 1 {
+		t.Fatalf("unexpected broadcasts %+v", broadcasts)
+	}
+	if ack := harness.Ack(alice); ack != 4 {
+		t.Fatalf("expected alice's ack to be 4, got %v", ack)
+	}
+
+	if err := harness.Leave(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+	if ack := harness.Ack(alice); ack != 0 {
+		t.Fatalf("expected alice's ack to be cleared on leave, got %v", ack)
+	}
+}
+
+// testEndMatch is a testMatch that ends with the sender of the first message it receives as the winner.
+type testEndMatch struct {
+	testMatch
+}
+
+func (m *testEndMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
+	if len(messages) > 0 {
+		_ = dispatcher.MatchEnd(&runtime.MatchResult{
+			Presences: []*runtime.MatchPresenceResult{{UserID: messages[0].GetUserId(), Username: messages[0].GetUsername(), Outcome: runtime.MatchOutcomeWin, Score: 10}},
+			Metadata:  map[string]interface{}{"map": "docks"},
+		})
+	}
+	return m.testMatch.MatchLoop(ctx, logger, db, nk, dispatcher, tick, state, messages)
+}
+
+func TestMatchHarnessEnd(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	harness := NewMatchHarness(&testEndMatch{}, nk)
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice := NewPresence(aliceID, "alice")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	if err := harness.Dispatcher().MatchEnd(&runtime.MatchResult{Presences: []*runtime.MatchPresenceResult{{Outcome: runtime.MatchOutcomeWin}}}); !errors.Is(err, runtime.ErrMatchResultInvalid) {
+		t.Fatalf("expected invalid result error, got %v", err)
+	}
+
+	harness.SendAt(2, alice, opCodeChat, []byte("gg"), true)
+	if err := harness.Run(ctx, 5); err != nil {
+		t.Fatal(err)
+	}
+	result := harness.Result()
+	if !harness.Stopped() || harness.CurrentTick() != 3 || result == nil || result.MatchID != harness.ID || result.Tick != 2 {
+		t.Fatalf("expected the match to end on tick 2, stopped %v at tick %v with result %+v", harness.Stopped(), harness.CurrentTick(), result)
+	}
+	if err := harness.Dispatcher().MatchEnd(result); !errors.Is(err, runtime.ErrMatchEnded) {
+		t.Fatalf("expected match ended error, got %v", err)
+	}
+
+	results, cursor, err := nk.MatchResultsList(ctx, "", aliceID, 10, "")
+	if err != nil || len(results) != 1 || cursor != "" || results[0].Presences[0].Outcome != runtime.MatchOutcomeWin || results[0].Metadata["map"] != "docks" {
+		t.Fatalf("unexpected results %v %q %v", results, cursor, err)
+	}
+	if results, _, _ := nk.MatchResultsList(ctx, "", bobID, 10, ""); len(results) != 0 {
+		t.Fatalf("expected no results for bob, got %v", results)
+	}
+
+	initializer := NewInitializer(nk)
+	var ended *runtime.MatchResult
+	if err := initializer.RegisterMatchEnd(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, result *runtime.MatchResult) error {
+		if mode, _ := ctx.Value(runtime.RUNTIME_CTX_MODE).(string); mode != "match_end" {
+			t.Errorf("unexpected mode %q", mode)
+		}
+		ended = result
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := initializer.MatchEnd(ctx, result); err != nil || ended != result {
+		t.Fatalf("expected the match end function to receive the result, got %v", err)
+	}
+}
+
+// testRelayMatch is a testMatch that sends chat messages from players back to itself from the match loop.
+type testRelayMatch struct {
+	testMatch
+}
+
+func (m *testRelayMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
+	id, _ := runtime.FromContext(ctx).MatchID.Get()
+	for _, message := range messages {
+		if message.GetOpCode() == opCodeChat && message.GetSessionId() != "" {
+			if err := nk.MatchSend(ctx, id, opCodeChat, append([]byte("relay "), message.GetData()...)); err != nil {
+				logger.Error("relay failed: %v", err)
+			}
+		}
+	}
+	return m.testMatch.MatchLoop(ctx, logger, db, nk, dispatcher, tick, state, messages)
+}
+
+func TestMatchHarnessMatchSend(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	harness := NewMatchHarness(&testMatch{}, nk)
+	if err := nk.MatchSend(ctx, harness.ID, opCodeChat, []byte("early")); !errors.Is(err, runtime.ErrMatchNotFound) {
+		t.Fatalf("expected match not found before init, got %v", err)
+	}
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice := NewPresence(aliceID, "alice")
+	_, _, _ = harness.Join(ctx, alice, nil)
+
+	if err := nk.MatchSend(ctx, harness.ID, opCodeChat, []byte("bracket")); err != nil {
+		t.Fatal(err)
+	}
+	if err := harness.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts := harness.Broadcasts()
+	if len(broadcasts) != 1 || string(broadcasts[0].Data) != "bracket" || broadcasts[0].Sender.GetSessionId() != "" {
+		t.Fatalf("expected the server message to be delivered without a presence, got %v", broadcasts)
+	}
+
+	if err := harness.Terminate(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := nk.MatchSend(ctx, harness.ID, opCodeChat, nil); !errors.Is(err, runtime.ErrMatchNotFound) {
+		t.Fatalf("expected match not found once stopped, got %v", err)
+	}
+
+	// Messages sent from inside the match loop are delivered on the following tick.
+	relay := NewMatchHarness(&testRelayMatch{}, nk)
+	if err := relay.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = relay.Join(ctx, alice, nil)
+	relay.Send(alice, opCodeChat, []byte("hello"), true)
+	if err := relay.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if broadcasts := relay.Broadcasts(); len(broadcasts) != 1 || string(broadcasts[0].Data) != "hello" {
+		t.Fatalf("expected only the player message on the first tick, got %v", broadcasts)
+	}
+	if err := relay.Tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	broadcasts = relay.Broadcasts()
+	if len(broadcasts) != 2 || string(broadcasts[1].Data) != "relay hello" || broadcasts[1].Sender.GetSessionId() != "" {
+		t.Fatalf("expected the message sent from the match loop on the next tick, got %v", broadcasts)
+	}
+}
+
+// testSlowMatch is a testMatch that runs at 60 ticks per second and overruns the ticks it receives messages on.
+type testSlowMatch struct {
+	testMatch
+}
+
+func (m *testSlowMatch) MatchInit(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, params map[string]interface{}) (interface{}, int, string) {
+	return &testMatchState{}, 60, ""
+}
+
+func (m *testSlowMatch) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
+	if len(messages) > 0 {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return m.testMatch.MatchLoop(ctx, logger, db, nk, dispatcher, tick, state, messages)
+}
+
+func TestMatchHarnessStats(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	initializer := NewInitializer(nk)
+	var overruns []time.Duration
+	if err := initializer.RegisterMatchTickOverrun(func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, stats *runtime.MatchStats, duration time.Duration) {
+		if stats.Module != "slow" || stats.TickOverruns != int64(len(overruns)+1) {
+			t.Errorf("unexpected overrun stats %+v", stats)
+		}
+		overruns = append(overruns, duration)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	slow := NewMatchHarness(&testSlowMatch{}, nk)
+	slow.Module, slow.OnTickOverrun = "slow", initializer.MatchTickOverrun
+	fast := NewMatchHarness(&testMatch{}, nk)
+	fast.Module = "fast"
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	for _, h := range []*MatchHarness{slow, fast} {
+		if err := h.Init(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+		_, _, _ = h.Join(ctx, alice, nil)
+		_, _, _ = h.Join(ctx, bob, nil)
+		h.Send(alice, opCodeChat, []byte("hello"), true)
+		if err := h.Run(ctx, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(overruns) != 1 || overruns[0] < 20*time.Millisecond {
+		t.Fatalf("expected a single overrun, got %v", overruns)
+	}
+	stats, err := nk.MatchStats(ctx, slow.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Ticks != 3 || stats.TickOverruns != 1 || stats.TickDurationMax < 20*time.Millisecond || stats.MessagesReceived != 1 || stats.MessagesSent != 1 || stats.BroadcastBytes != 10 || stats.Presences != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	slow.ClearRecorded()
+	if stats = slow.Stats(); stats.MessagesSent != 1 || stats.BroadcastBytes != 10 || len(slow.Broadcasts()) != 0 {
+		t.Fatalf("expected clearing recorded broadcasts to keep the stats, got %+v", stats)
+	}
+	if _, err := nk.MatchStats(ctx, "missing"); !errors.Is(err, runtime.ErrMatchNotFound) {
+		t.Fatalf("expected match not found, got %v", err)
+	}
+
+	modules, err := nk.MatchModuleStats(ctx)
+	if err != nil || len(modules) != 2 || modules[0].Module != "fast" || modules[1].Module != "slow" {
+		t.Fatalf("unexpected module stats %v %v", modules, err)
+	}
+	if modules[0].Matches != 1 || modules[0].Ticks != 3 || modules[0].TickOverruns != 0 || modules[0].MatchID != "" {
+		t.Fatalf("unexpected fast module stats %+v", modules[0])
+	}
+}
+
+func TestMatchHarnessMatchList(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	watched, open := NewMatchHarness(&testMatch{}, nk), NewMatchHarness(&testMatch{}, nk)
+	for _, h := range []*MatchHarness{watched, open} {
+		if err := h.Init(ctx, map[string]interface{}{"label": "open"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, _, _ = watched.Join(ctx, NewPresence(aliceID, "alice"), nil)
+	_, _, _ = watched.Join(ctx, NewSpectator(bobID, "bob"), nil)
+
+	matches, err := nk.MatchList(ctx, 10, true, "open", nil, nil, "")
+	if err != nil || len(matches) != 1 || matches[0].GetMatchId() != open.ID || matches[0].GetSize() != 0 {
+		t.Fatalf("expected only the open match to keep its label, got %v %v", matches, err)
+	}
+
+	one := 1
+	matches, err = nk.MatchListWithOptions(ctx, &runtime.MatchListOptions{Limit: 10, Authoritative: true, MinSpectators: &one})
+	if err != nil || len(matches) != 1 || matches[0].GetMatchId() != watched.ID || matches[0].GetSize() != 1 || matches[0].GetSpectatorCount() != 1 || matches[0].GetLabel().GetValue() != "joined" {
+		t.Fatalf("expected only the watched match, got %v %v", matches, err)
+	}
+	zero := 0
+	matches, err = nk.MatchListWithOptions(ctx, &runtime.MatchListOptions{Limit: 10, Authoritative: true, MinSize: &one, MaxSpectators: &zero})
+	if err != nil || len(matches) != 0 {
+		t.Fatalf("expected no matches, got %v %v", matches, err)
+	}
+	if matches, err = nk.MatchList(ctx, 1, true, "", nil, nil, ""); err != nil || len(matches) != 1 {
+		t.Fatalf("expected the limit to be applied, got %v %v", matches, err)
+	}
+	if matches, err = nk.MatchList(ctx, 10, false, "", nil, nil, ""); err != nil || len(matches) != 0 {
+		t.Fatalf("expected no relayed matches, got %v %v", matches, err)
+	}
+	if _, err = nk.MatchList(ctx, 10, true, "", nil, nil, "+label.mode:ranked"); !errors.Is(err, ErrNotImplemented) {
+		t.Fatalf("expected label queries to be unsupported, got %v", err)
+	}
+}
+
+// testBackfillMatch is a testMatch that backfills the slots of the presences that leave with ranked players, and
+// only accepts backfilled players with a skill of at least 1000.
+type testBackfillMatch struct {
+	testMatch
+}
+
+func (m *testBackfillMatch) MatchJoinAttempt(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presence runtime.Presence, metadata map[string]string) (interface{}, bool, string) {
+	if entry, ok := runtime.FromContext(ctx).MatchmakerEntry.Get(); ok {
+		if skill, _ := entry.GetProperties()["skill"].(float64); skill < 1000 {
+			return state, false, "skill too low"
+		}
+	}
+	return m.testMatch.MatchJoinAttempt(ctx, logger, db, nk, dispatcher, tick, state, presence, metadata)
+}
+
+func (m *testBackfillMatch) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
+	state = m.testMatch.MatchLeave(ctx, logger, db, nk, dispatcher, tick, state, presences)
+	s := state.(*testMatchState)
+	_ = dispatcher.SetMatchmakerBackfill(s.left, "+properties.mode:ranked", map[string]string{"mode": "ranked"}, nil)
+	return state
+}
+
+func TestMatchHarnessBackfill(t *testing.T) {
+	ctx := context.Background()
+	harness := NewMatchHarness(&testBackfillMatch{}, NewNakamaModule())
+	if err := harness.Init(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := NewPresence(aliceID, "alice"), NewPresence(bobID, "bob")
+	_, _, _ = harness.Join(ctx, alice, nil)
+	_, _, _ = harness.Join(ctx, bob, nil)
+	if err := harness.Dispatcher().SetMatchmakerBackfill(-1, "", nil, nil); !errors.Is(err, runtime.ErrMatchBackfillInvalid) {
+		t.Fatalf("expected invalid backfill error, got %v", err)
+	}
+	if err := harness.Leave(ctx, bob); err != nil {
+		t.Fatal(err)
+	}
+	if backfill := harness.Backfill(); backfill == nil || backfill.Slots != 1 || backfill.StringProperties["mode"] != "ranked" {
+		t.Fatalf("expected a backfill slot for bob, got %+v", backfill)
+	}
+
+	casual := &MatchmakerEntry{Presence: NewPresence(generateID(), "carol"), Properties: map[string]interface{}{"mode": "casual", "skill": 1500.0}}
+	novice := &MatchmakerEntry{Presence: NewPresence(generateID(), "dave"), Properties: map[string]interface{}{"mode": "ranked", "skill": 500.0}}
+	expert := &MatchmakerEntry{Presence: NewPresence(generateID(), "erin"), Properties: map[string]interface{}{"mode": "ranked", "skill": 1500.0}}
+	late := &MatchmakerEntry{Presence: NewPresence(generateID(), "frank"), Properties: map[string]interface{}{"mode": "ranked", "skill": 1500.0}}
+	// The ticket queries of picky and queued reject the ranked backfill, although their properties match its query.
+	picky := &MatchmakerEntry{Presence: NewPresence(generateID(), "grace"), Properties: map[string]interface{}{"mode": "ranked", "skill": 1500.0}, Query: "+properties.mode:casual"}
+	queuedSession := generateID()
+	ticket, err := harness.NK.MatchmakerAdd(ctx, queuedSession, "+properties.mode:casual", 2, 4, 1, map[string]string{"mode": "ranked"}, map[string]float64{"skill": 1500}, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued := &MatchmakerEntry{Presence: &Presence{UserID: generateID(), SessionID: queuedSession, Username: "heidi"}, Ticket: ticket, Properties: map[string]interface{}{"mode": "ranked", "skill": 1500.0}}
+	joined, err := harness.FillBackfill(ctx, casual, novice, picky, queued, expert, late)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(joined) != 1 || joined[0] != expert || len(harness.Presences()) != 2 || harness.Backfill() != nil {
+		t.Fatalf("expected erin to fill the slot, got %v with backfill %+v", joined, harness.Backfill())
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// AddMatchResult stores the result of a match as if the match had ended through MatchDispatcher.MatchEnd. A
+// MatchHarness does this for the matches it runs when its NK is this module.
+func (n *NakamaModule) AddMatchResult(result *runtime.MatchResult) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.matchResults = append(n.matchResults, cloneMatchResult(result))
+}
+
+func (n *NakamaModule) MatchResultsList(ctx context.Context, matchID, userID string, limit int, cursor string) ([]*runtime.MatchResult, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	results := make([]*runtime.MatchResult, 0)
+	for _, result := range slices.Backward(n.matchResults) {
+		switch {
+		case matchID != "" && result.MatchID != matchID:
+		case userID != "" && !slices.ContainsFunc(result.Presences, func(p *runtime.MatchPresenceResult) bool { return p.UserID == userID }):
+		default:
+			results = append(results, result)
+		}
+	}
+
+	start, end, next, err := paginate(cursor, limit, len(results))
+	if err != nil {
+		return nil, "", err
+	}
+	page := make([]*runtime.MatchResult, 0, end-start)
+	for _, result := range results[start:end] {
+		page = append(page, cloneMatchResult(result))
+	}
+	return page, next, nil
+}
+
+// MatchSend queues the message for delivery to MatchLoop on the next tick of the match, which must be run by a
+// MatchHarness that has this module as its NK.
+func (n *NakamaModule) MatchSend(ctx context.Context, id string, opCode int64, data []byte) error {
+	n.mu.Lock()
+	h := n.matches[id]
+	n.mu.Unlock()
+
+	if h == nil {
+		return runtime.ErrMatchNotFound
+	}
+	if err := h.running(); err != nil {
+		return err
+	}
+	h.SendData(h.nextTick(), &MatchData{OpCode: opCode, Data: slices.Clone(data), Reliable: true})
+	return nil
+}
+
+// MatchStats returns the stats of a match run by a MatchHarness that has this module as its NK.
+func (n *NakamaModule) MatchStats(ctx context.Context, id string) (*runtime.MatchStats, error) {
+	n.mu.Lock()
+	h := n.matches[id]
+	n.mu.Unlock()
+
+	if h == nil {
+		return nil, runtime.ErrMatchNotFound
+	}
+	if err := h.running(); err != nil {
+		return nil, err
+	}
+	return h.Stats(), nil
+}
+
+// MatchModuleStats returns the totals of the stats of the running matches of each module, ordered by module name.
+func (n *NakamaModule) MatchModuleStats(ctx context.Context) ([]*runtime.MatchStats, error) {
+	n.mu.Lock()
+	harnesses := slices.Collect(maps.Values(n.matches))
+	n.mu.Unlock()
+
+	modules := make(map[string]*runtime.MatchStats)
+	for _, h := range harnesses {
+		if h.running() != nil {
+			continue
+		}
+		stats := h.Stats()
+		total := modules[stats.Module]
+		if total == nil {
+			total = &runtime.MatchStats{Module: stats.Module}
+			modules[stats.Module] = total
+		}
+		if ticks := total.Ticks + stats.Ticks; ticks > 0 {
+			total.TickDurationAvg = (total.TickDurationAvg*time.Duration(total.Ticks) + stats.TickDurationAvg*time.Duration(stats.Ticks)) / time.Duration(ticks)
+		}
+		total.Matches++
+		total.Ticks += stats.Ticks
+		total.TickDurationMax = max(total.TickDurationMax, stats.TickDurationMax)
+		total.TickOverruns += stats.TickOverruns
+		total.MessagesReceived += stats.MessagesReceived
+		total.MessagesSent += stats.MessagesSent
+		total.BroadcastBytes += stats.BroadcastBytes
+		total.Presences += stats.Presences
+		total.Spectators += stats.Spectators
+	}
+	return slices.SortedFunc(maps.Values(modules), func(a, b *runtime.MatchStats) int {
+		return strings.Compare(a.Module, b.Module)
+	}), nil
+}
+
+// MatchList lists the running matches of the MatchHarnesses that have this module as their NK, as
+// MatchListWithOptions does.
+func (n *NakamaModule) MatchList(ctx context.Context, limit int, authoritative bool, label string, minSize, maxSize *int, query string) ([]*api.Match, error) {
+	return n.MatchListWithOptions(ctx, &runtime.MatchListOptions{
+		Limit:         limit,
+		Authoritative: authoritative,
+		Label:         label,
+		MinSize:       minSize,
+		MaxSize:       maxSize,
+		Query:         query,
+	})
+}
+
+// MatchListWithOptions lists the running matches of the MatchHarnesses that have this module as their NK, ordered by
+// match ID. The harness matches are all authoritative, so none are listed when Authoritative is false. Label queries
+// need the server's match index, so a non-empty Query returns ErrNotImplemented.
+func (n *NakamaModule) MatchListWithOptions(ctx context.Context, options *runtime.MatchListOptions) ([]*api.Match, error) {
+	if options == nil {
+		return nil, runtime.NewError("expects match list options", 3)
+	}
+	if options.Query != "" {
+		return nil, ErrNotImplemented
+	}
+
+	n.mu.Lock()
+	harnesses := slices.SortedFunc(maps.Values(n.matches), func(a, b *MatchHarness) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	n.mu.Unlock()
+
+	matches := make([]*api.Match, 0)
+	if !options.Authoritative {
+		return matches, nil
+	}
+	for _, h := range harnesses {
+		if len(matches) >= options.Limit {
+			break
+		}
+		if h.running() != nil {
+			continue
+		}
+		stats := h.Stats()
+		label := h.Label()
+		if options.Label != "" && label != options.Label {
+			continue
+		}
+		if !inRange(stats.Presences, options.MinSize, options.MaxSize) || !inRange(stats.Spectators, options.MinSpectators, options.MaxSpectators) {
+			continue
+		}
+		matches = append(matches, &api.Match{
+			MatchId:        h.ID,
+			Authoritative:  true,
+			Label:          wrapperspb.String(label),
+			Size:           int32(stats.Presences),
+			TickRate:       int32(h.TickRate()),
+			HandlerName:    stats.Module,
+			SpectatorCount: int32(stats.Spectators),
+		})
+	}
+	return matches, nil
+}
+
+// inRange returns true if the value is within the bounds, where a nil bound is not checked.
+func inRange(value int, lower, upper *int) bool {
+	return (lower == nil || value >= *lower) && (upper == nil || value <= *upper)
+}
+
+func cloneMatchResult(result *runtime.MatchResult) *runtime.MatchResult {
+	clone := *result
+	clone.Metadata = maps.Clone(result.Metadata)
+	clone.Presences = make([]*runtime.MatchPresenceResult, 0, len(result.Presences))
+	for _, presence := range result.Presences {
+		p := *presence
+		p.Metadata = maps.Clone(presence.Metadata)
+		clone.Presences = append(clone.Presences, &p)
+	}
+	return &clone
+}
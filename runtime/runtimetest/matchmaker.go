@@ -0,0 +1,278 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// DefaultMatchmakerMaxTickets is the number of matchmaker tickets a session or party may hold at once by default.
+const DefaultMatchmakerMaxTickets = 3
+
+var _ runtime.MatchmakerEntry = (*MatchmakerEntry)(nil)
+
+// MatchmakerEntry is a runtime.MatchmakerEntry with exported fields. Properties holds the string and numeric
+// properties of the ticket, as strings and float64 values. Query holds the query of the ticket, which is not part of
+// runtime.MatchmakerEntry and is only used by MatchHarness.FillBackfill.
+type MatchmakerEntry struct {
+	Presence   *Presence
+	Ticket     string
+	Properties map[string]interface{}
+	PartyID    string
+	CreateTime int64
+	Stage      int
+	Latencies  map[string]float32
+	Query      string
+}
+
+func (e *MatchmakerEntry) GetPresence() runtime.Presence {
+	if e.Presence == nil {
+		return nil
+	}
+	return e.Presence
+}
+
+func (e *MatchmakerEntry) GetTicket() string {
+	return e.Ticket
+}
+
+func (e *MatchmakerEntry) GetProperties() map[string]interface{} {
+	return e.Properties
+}
+
+func (e *MatchmakerEntry) GetPartyId() string {
+	return e.PartyID
+}
+
+func (e *MatchmakerEntry) GetCreateTime() int64 {
+	return e.CreateTime
+}
+
+func (e *MatchmakerEntry) GetStage() int {
+	return e.Stage
+}
+
+func (e *MatchmakerEntry) GetLatencies() map[string]float32 {
+	return e.Latencies
+}
+
+// validateMatchmakerTicket applies the checks the server makes on a matchmaker add request.
+func validateMatchmakerTicket(query string, minCount, maxCount, countMultiple int, stages []runtime.MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) error {
+	switch {
+	case minCount < 2:
+		return runtime.NewError("Invalid minimum count, must be >= 2", 3)
+	case maxCount < 2:
+		return runtime.NewError("Invalid maximum count, must be >= 2", 3)
+	case minCount > maxCount:
+		return runtime.NewError("Invalid count range, minimum count must be <= maximum count", 3)
+	case countMultiple < 1:
+		return runtime.NewError("Invalid count multiple, must be >= 1", 3)
+	case minCount%countMultiple != 0:
+		return runtime.NewError("Invalid count multiple for minimum count, must divide", 3)
+	case maxCount%countMultiple != 0:
+		return runtime.NewError("Invalid count multiple for maximum count, must divide", 3)
+	case ttl < 0:
+		return runtime.NewError("Invalid ticket TTL, must be >= 0", 3)
+	}
+	for _, latency := range latencies {
+		if latency < 0 {
+			return runtime.NewError("Invalid latency, must be >= 0", 3)
+		}
+	}
+	if _, err := runtime.ParseMatchmakerQuery(query); err != nil {
+		return err
+	}
+	var after time.Duration
+	for _, stage := range stages {
+		if stage.After <= after {
+			return runtime.ErrMatchmakerStagesInvalid
+		}
+		after = stage.After
+		if _, err := runtime.ParseMatchmakerQuery(stage.Query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchmakerTicket is a queued ticket with the exact time it expires at, which is zero for tickets without a TTL. The
+// ExpiryTime of the ticket is only precise to the second.
+type matchmakerTicket struct {
+	*runtime.MatchmakerTicket
+	expires time.Time
+}
+
+// expireTicketsLocked removes the tickets whose TTL has passed.
+func (n *NakamaModule) expireTicketsLocked() {
+	now := n.Now()
+	n.tickets = slices.DeleteFunc(n.tickets, func(t *matchmakerTicket) bool {
+		return !t.expires.IsZero() && !t.expires.After(now)
+	})
+}
+
+// matchmakerAdd adds a ticket, setting the rating properties of the user if one is given, or of each member of the
+// party for party tickets.
+func (n *NakamaModule) matchmakerAdd(userID, sessionID, partyID, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64, stages []runtime.MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) (string, error) {
+	if query == "" {
+		query = "*"
+	}
+	if countMultiple == 0 {
+		countMultiple = 1
+	}
+	if err := validateMatchmakerTicket(query, minCount, maxCount, countMultiple, stages, ttl, latencies); err != nil {
+		return "", err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.expireTicketsLocked()
+
+	held := 0
+	for _, ticket := range n.tickets {
+		if ticket.SessionID == sessionID && ticket.PartyID == partyID {
+			held++
+		}
+	}
+	if held >= n.MatchmakerMaxTickets {
+		return "", runtime.ErrMatchmakerTooManyTickets
+	}
+
+	numericProperties = maps.Clone(numericProperties)
+	if userID != "" {
+		if ratings := n.ratingPropertiesLocked(userID, numericProperties); len(ratings) > 0 {
+			if numericProperties == nil {
+				numericProperties = make(map[string]float64, len(ratings))
+			}
+			maps.Copy(numericProperties, ratings)
+		}
+	}
+	var memberProperties map[string]map[string]float64
+	if partyID != "" {
+		for _, member := range n.parties[partyID] {
+			if ratings := n.ratingPropertiesLocked(member, numericProperties); len(ratings) > 0 {
+				if memberProperties == nil {
+					memberProperties = make(map[string]map[string]float64)
+				}
+				memberProperties[member] = ratings
+			}
+		}
+	}
+	now := n.Now()
+	var expires time.Time
+	var expiryTime int64
+	if ttl > 0 {
+		expires = now.Add(ttl)
+		expiryTime = expires.Unix()
+	}
+	ticket := &runtime.MatchmakerTicket{
+		Ticket:                  generateID(),
+		SessionID:               sessionID,
+		PartyID:                 partyID,
+		Query:                   query,
+		MinCount:                minCount,
+		MaxCount:                maxCount,
+		CountMultiple:           countMultiple,
+		StringProperties:        maps.Clone(stringProperties),
+		NumericProperties:       numericProperties,
+		MemberNumericProperties: memberProperties,
+		Latencies:               maps.Clone(latencies),
+		Stages:                  slices.Clone(stages),
+		CreateTime:              now.Unix(),
+		ExpiryTime:              expiryTime,
+	}
+	n.tickets = append(n.tickets, &matchmakerTicket{MatchmakerTicket: ticket, expires: expires})
+	return ticket.Ticket, nil
+}
+
+func (n *NakamaModule) MatchmakerAdd(ctx context.Context, sessionID, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64, stages []runtime.MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) (string, error) {
+	if sessionID == "" {
+		return "", runtime.NewError("expects session id", 3)
+	}
+	// The fake does not track sessions, so ratings are only added for the user of a context holding the session.
+	rc := runtime.FromContext(ctx)
+	userID, _ := rc.UserID.Get()
+	if ctxSessionID, _ := rc.SessionID.Get(); ctxSessionID != sessionID {
+		userID = ""
+	}
+	return n.matchmakerAdd(userID, sessionID, "", query, minCount, maxCount, countMultiple, stringProperties, numericProperties, stages, ttl, latencies)
+}
+
+// AddPartyMembers adds users to the members of a party, whose ratings MatchmakerPartyAdd sets on the tickets of the
+// party. The fake does not otherwise track parties.
+func (n *NakamaModule) AddPartyMembers(partyID string, userIDs ...string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if !slices.Contains(n.parties[partyID], userID) {
+			n.parties[partyID] = append(n.parties[partyID], userID)
+		}
+	}
+}
+
+func (n *NakamaModule) MatchmakerPartyAdd(ctx context.Context, partyID, query string, minCount, maxCount, countMultiple int, stringProperties map[string]string, numericProperties map[string]float64, stages []runtime.MatchmakerQueryStage, ttl time.Duration, latencies map[string]float32) (string, error) {
+	if partyID == "" {
+		return "", runtime.NewError("expects party id", 3)
+	}
+	return n.matchmakerAdd("", "", partyID, query, minCount, maxCount, countMultiple, stringProperties, numericProperties, stages, ttl, latencies)
+}
+
+func (n *NakamaModule) MatchmakerRemove(ctx context.Context, ticket string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.expireTicketsLocked()
+	i := slices.IndexFunc(n.tickets, func(t *matchmakerTicket) bool { return t.Ticket == ticket })
+	if i < 0 {
+		return runtime.ErrMatchmakerTicketNotFound
+	}
+	n.tickets = slices.Delete(n.tickets, i, i+1)
+	return nil
+}
+
+func (n *NakamaModule) MatchmakerTicketList(ctx context.Context, sessionID, partyID string) ([]*runtime.MatchmakerTicket, error) {
+	if sessionID == "" && partyID == "" {
+		return nil, runtime.NewError("expects session id or party id", 3)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.expireTicketsLocked()
+	now := n.Now()
+	tickets := make([]*runtime.MatchmakerTicket, 0)
+	for _, ticket := range n.tickets {
+		if (sessionID != "" && ticket.SessionID == sessionID) || (partyID != "" && ticket.PartyID == partyID) {
+			t := *ticket.MatchmakerTicket
+			t.StringProperties, t.NumericProperties = maps.Clone(ticket.StringProperties), maps.Clone(ticket.NumericProperties)
+			if ticket.MemberNumericProperties != nil {
+				t.MemberNumericProperties = make(map[string]map[string]float64, len(ticket.MemberNumericProperties))
+				for member, properties := range ticket.MemberNumericProperties {
+					t.MemberNumericProperties[member] = maps.Clone(properties)
+				}
+			}
+			t.Latencies, t.Stages = maps.Clone(ticket.Latencies), slices.Clone(ticket.Stages)
+			t.Stage, _ = ticket.StageAt(now)
+			tickets = append(tickets, &t)
+		}
+	}
+	return tickets, nil
+}
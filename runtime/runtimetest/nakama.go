@@ -0,0 +1,476 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package runtimetest provides in-memory implementations of the runtime interfaces for use in unit tests.
+
+The NakamaModule in this package keeps all of its state in memory and implements the subset of the server
+behaviour that module code most commonly depends on: accounts, storage, wallets, notifications, friends, groups,
+leaderboards, matchmaker tickets, match results and ratings. Functions that require a real server, such as purchase
+validation or realtime streams, return ErrNotImplemented.
+
+The Initializer records the functions registered by a module's InitModule so that tests can invoke RPCs, hooks
+and other registered functions directly, and the MatchHarness drives a runtime.Match through its lifecycle one tick
+at a time.
+
+	func TestRewardRpc(t *testing.T) {
+		nk := runtimetest.NewNakamaModule()
+		nk.AddUser("5a9e5cb2-4b5d-4d83-a0b8-e3d0d19b6a2f", "alice")
+
+		if _, err := rewardRpc(context.Background(), runtimetest.NewLogger(t), nil, nk, "{}"); err != nil {
+			t.Fatal(err)
+		}
+		account, _ := nk.AccountGetId(context.Background(), "5a9e5cb2-4b5d-4d83-a0b8-e3d0d19b6a2f")
+		...
+	}
+*/
+package runtimetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SystemUserID is the owner of storage objects and other resources that do not belong to a user.
+const SystemUserID = "00000000-0000-0000-0000-000000000000"
+
+var (
+	// ErrNotImplemented is returned by functions that have no in-memory implementation.
+	ErrNotImplemented = errors.New("not implemented by runtimetest")
+
+	errAccountNotFound = errors.New("account not found")
+	errInvalidCursor   = errors.New("cursor is invalid")
+)
+
+var _ runtime.NakamaModule = (*NakamaModule)(nil)
+
+type account struct {
+	user     *api.User
+	wallet   map[string]int64
+	email    string
+	password string
+	customID string
+	devices  []string
+	disabled bool
+}
+
+// NakamaModule is an in-memory implementation of runtime.NakamaModule. It is safe for concurrent use.
+type NakamaModule struct {
+	mu sync.Mutex
+
+	// Now returns the current time, and may be replaced to control timestamps in tests.
+	Now func() time.Time
+	// MatchmakerMaxTickets is the number of matchmaker tickets a session or party may hold at once, matching the
+	// server's default matchmaker max tickets.
+	MatchmakerMaxTickets int
+
+	seq           int64
+	accounts      map[string]*account
+	usernames     map[string]string
+	customIDs     map[string]string
+	deviceIDs     map[string]string
+	emails        map[string]string
+	storage       map[storageKey]*api.StorageObject
+	walletLedger  []*walletLedgerItem
+	notifications map[string][]*api.Notification
+	sent          []*sentNotification
+	friends       map[string]map[string]*friendEdge
+	groups        map[string]*group
+	groupNames    map[string]string
+	leaderboards  map[string]*leaderboard
+	tickets       []*matchmakerTicket
+	parties       map[string][]string
+	matchResults  []*runtime.MatchResult
+	matches       map[string]*MatchHarness
+	rulesets      map[string]*runtime.RatingRuleset
+	ratings       map[ratingKey]*runtime.Rating
+	ratingLedger  []*runtime.RatingLedgerItem
+}
+
+// NewNakamaModule returns an empty in-memory NakamaModule.
+func NewNakamaModule() *NakamaModule {
+	return &NakamaModule{
+		Now:                  time.Now,
+		MatchmakerMaxTickets: DefaultMatchmakerMaxTickets,
+
+		accounts:      make(map[string]*account),
+		usernames:     make(map[string]string),
+		customIDs:     make(map[string]string),
+		deviceIDs:     make(map[string]string),
+		emails:        make(map[string]string),
+		storage:       make(map[storageKey]*api.StorageObject),
+		notifications: make(map[string][]*api.Notification),
+		friends:       make(map[string]map[string]*friendEdge),
+		groups:        make(map[string]*group),
+		groupNames:    make(map[string]string),
+		leaderboards:  make(map[string]*leaderboard),
+		parties:       make(map[string][]string),
+		matches:       make(map[string]*MatchHarness),
+		rulesets:      make(map[string]*runtime.RatingRuleset),
+		ratings:       make(map[ratingKey]*runtime.Rating),
+	}
+}
+
+// AddUser creates a user account with the given ID and username. An existing account with the same ID is replaced.
+func (n *NakamaModule) AddUser(userID, username string) *api.User {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return proto.Clone(n.addUserLocked(userID, username).user).(*api.User)
+}
+
+func (n *NakamaModule) addUserLocked(userID, username string) *account {
+	if existing, ok := n.accounts[userID]; ok {
+		delete(n.usernames, existing.user.Username)
+	}
+	if username == "" {
+		username = generateUsername()
+	}
+	now := timestamppb.New(n.Now())
+	a := &account{
+		user: &api.User{
+			Id:         userID,
+			Username:   username,
+			Metadata:   "{}",
+			CreateTime: now,
+			UpdateTime: now,
+		},
+		wallet: make(map[string]int64),
+	}
+	n.accounts[userID] = a
+	n.usernames[username] = userID
+	return a
+}
+
+// authenticate finds the account linked to the ID, or creates it and links the ID with link if create is set. The
+// credentials of an existing account are checked with verify, if given, under the same lock.
+func (n *NakamaModule) authenticate(ids map[string]string, id, username string, create bool, verify func(a *account) error, link func(a *account)) (string, string, bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if userID, ok := ids[id]; ok {
+		a := n.accounts[userID]
+		if verify != nil {
+			if err := verify(a); err != nil {
+				return "", "", false, err
+			}
+		}
+		if a.disabled {
+			return "", "", false, errors.New("error finding user account, account is disabled")
+		}
+		return userID, a.user.Username, false, nil
+	}
+	if !create {
+		return "", "", false, errAccountNotFound
+	}
+	if username != "" {
+		if _, ok := n.usernames[username]; ok {
+			return "", "", false, errors.New("username is already in use")
+		}
+	}
+
+	a := n.addUserLocked(generateID(), username)
+	ids[id] = a.user.Id
+	link(a)
+	return a.user.Id, a.user.Username, true, nil
+}
+
+func (n *NakamaModule) AuthenticateCustom(ctx context.Context, id, username string, create bool) (string, string, bool, error) {
+	if id == "" {
+		return "", "", false, errors.New("expects id string")
+	}
+	return n.authenticate(n.customIDs, id, username, create, nil, func(a *account) { a.customID = id })
+}
+
+func (n *NakamaModule) AuthenticateDevice(ctx context.Context, id, username string, create bool) (string, string, bool, error) {
+	if id == "" {
+		return "", "", false, errors.New("expects id string")
+	}
+	return n.authenticate(n.deviceIDs, id, username, create, nil, func(a *account) { a.devices = append(a.devices, id) })
+}
+
+func (n *NakamaModule) AuthenticateEmail(ctx context.Context, email, password, username string, create bool) (string, string, bool, error) {
+	if email == "" {
+		return "", "", false, errors.New("expects email string")
+	}
+	verify := func(a *account) error {
+		if a.password != password {
+			return errors.New("invalid credentials")
+		}
+		return nil
+	}
+	return n.authenticate(n.emails, email, username, create, verify, func(a *account) {
+		a.email = email
+		a.password = password
+	})
+}
+
+func (n *NakamaModule) AccountGetId(ctx context.Context, userID string) (*api.Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	a, ok := n.accounts[userID]
+	if !ok {
+		return nil, errAccountNotFound
+	}
+	return n.exportAccountLocked(a), nil
+}
+
+func (n *NakamaModule) AccountsGetId(ctx context.Context, userIDs []string) ([]*api.Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	accounts := make([]*api.Account, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if a, ok := n.accounts[userID]; ok {
+			accounts = append(accounts, n.exportAccountLocked(a))
+		}
+	}
+	return accounts, nil
+}
+
+func (n *NakamaModule) exportAccountLocked(a *account) *api.Account {
+	wallet, _ := json.Marshal(a.wallet)
+	devices := make([]*api.AccountDevice, 0, len(a.devices))
+	for _, id := range a.devices {
+		devices = append(devices, &api.AccountDevice{Id: id})
+	}
+	account := &api.Account{
+		User:     proto.Clone(a.user).(*api.User),
+		Wallet:   string(wallet),
+		Email:    a.email,
+		Devices:  devices,
+		CustomId: a.customID,
+	}
+	account.User.EdgeCount = int32(n.friendCountLocked(a.user.Id))
+	return account
+}
+
+func (n *NakamaModule) AccountUpdateId(ctx context.Context, userID, username string, metadata map[string]interface{}, displayName, timezone, location, langTag, avatarUrl string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.accountUpdateLocked(&runtime.AccountUpdate{
+		UserID:      userID,
+		Username:    username,
+		Metadata:    metadata,
+		DisplayName: displayName,
+		Timezone:    timezone,
+		Location:    location,
+		LangTag:     langTag,
+		AvatarUrl:   avatarUrl,
+	})
+}
+
+func (n *NakamaModule) validateAccountUpdateLocked(update *runtime.AccountUpdate) error {
+	a, ok := n.accounts[update.UserID]
+	if !ok {
+		return errAccountNotFound
+	}
+	if update.Username != "" && update.Username != a.user.Username {
+		if _, ok := n.usernames[update.Username]; ok {
+			return errors.New("username is already in use")
+		}
+	}
+	if update.Metadata != nil {
+		if _, err := json.Marshal(update.Metadata); err != nil {
+			return fmt.Errorf("error encoding metadata: %v", err.Error())
+		}
+	}
+	return nil
+}
+
+func (n *NakamaModule) accountUpdateLocked(update *runtime.AccountUpdate) error {
+	if err := n.validateAccountUpdateLocked(update); err != nil {
+		return err
+	}
+
+	a := n.accounts[update.UserID]
+	if update.Username != "" && update.Username != a.user.Username {
+		delete(n.usernames, a.user.Username)
+		n.usernames[update.Username] = a.user.Id
+		a.user.Username = update.Username
+	}
+	if update.Metadata != nil {
+		metadata, _ := json.Marshal(update.Metadata)
+		a.user.Metadata = string(metadata)
+	}
+	for dst, src := range map[*string]string{
+		&a.user.DisplayName: update.DisplayName,
+		&a.user.Timezone:    update.Timezone,
+		&a.user.Location:    update.Location,
+		&a.user.LangTag:     update.LangTag,
+		&a.user.AvatarUrl:   update.AvatarUrl,
+	} {
+		if src != "" {
+			*dst = src
+		}
+	}
+	a.user.UpdateTime = timestamppb.New(n.Now())
+	return nil
+}
+
+func (n *NakamaModule) AccountDeleteId(ctx context.Context, userID string, recorded bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	a, ok := n.accounts[userID]
+	if !ok {
+		return nil
+	}
+	delete(n.accounts, userID)
+	delete(n.usernames, a.user.Username)
+	for _, ids := range []map[string]string{n.customIDs, n.deviceIDs, n.emails} {
+		for id, owner := range ids {
+			if owner == userID {
+				delete(ids, id)
+			}
+		}
+	}
+	for key := range n.storage {
+		if key.userID == userID {
+			delete(n.storage, key)
+		}
+	}
+	for friendID := range n.friends[userID] {
+		delete(n.friends[friendID], userID)
+	}
+	delete(n.friends, userID)
+	delete(n.notifications, userID)
+	for _, g := range n.groups {
+		delete(g.members, userID)
+	}
+	return nil
+}
+
+func (n *NakamaModule) UsersGetId(ctx context.Context, userIDs []string, facebookIDs []string) ([]*api.User, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	users := make([]*api.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if a, ok := n.accounts[userID]; ok {
+			users = append(users, proto.Clone(a.user).(*api.User))
+		}
+	}
+	return users, nil
+}
+
+func (n *NakamaModule) UsersGetUsername(ctx context.Context, usernames []string) ([]*api.User, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	users := make([]*api.User, 0, len(usernames))
+	for _, username := range usernames {
+		if userID, ok := n.usernames[username]; ok {
+			users = append(users, proto.Clone(n.accounts[userID].user).(*api.User))
+		}
+	}
+	return users, nil
+}
+
+func (n *NakamaModule) UsersBanId(ctx context.Context, userIDs []string) error {
+	return n.setDisabled(userIDs, true)
+}
+
+func (n *NakamaModule) UsersUnbanId(ctx context.Context, userIDs []string) error {
+	return n.setDisabled(userIDs, false)
+}
+
+func (n *NakamaModule) setDisabled(userIDs []string, disabled bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if a, ok := n.accounts[userID]; ok {
+			a.disabled = disabled
+		}
+	}
+	return nil
+}
+
+// Event accepts and discards the event.
+func (n *NakamaModule) Event(ctx context.Context, evt *api.Event) error {
+	return nil
+}
+
+func (n *NakamaModule) MetricsCounterAdd(name string, tags map[string]string, delta int64) {}
+
+func (n *NakamaModule) MetricsGaugeSet(name string, tags map[string]string, value float64) {}
+
+func (n *NakamaModule) MetricsTimerRecord(name string, tags map[string]string, value time.Duration) {}
+
+// GetSatori returns nil as there is no in-memory Satori client.
+func (n *NakamaModule) GetSatori() runtime.Satori {
+	return nil
+}
+
+// GetFleetManager returns nil as there is no in-memory fleet manager.
+func (n *NakamaModule) GetFleetManager() runtime.FleetManager {
+	return nil
+}
+
+// nextSeqLocked returns an increasing sequence number used to keep insertion order stable.
+func (n *NakamaModule) nextSeqLocked() int64 {
+	n.seq++
+	return n.seq
+}
+
+func generateID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func generateUsername() string {
+	var b [5]byte
+	_, _ = rand.Read(b[:])
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	username := make([]byte, len(b)*2)
+	for i, v := range b {
+		username[i*2] = letters[int(v>>4)%len(letters)]
+		username[i*2+1] = letters[int(v&0x0f)+26]
+	}
+	return string(username)
+}
+
+// paginate returns the bounds of the page starting at the offset encoded in cursor, and the cursor for the next page.
+func paginate(cursor string, limit, total int) (start, end int, next string, err error) {
+	if cursor != "" {
+		if start, err = strconv.Atoi(cursor); err != nil || start < 0 || start > total {
+			return 0, 0, "", errInvalidCursor
+		}
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	end = start + limit
+	if end >= total {
+		return start, total, "", nil
+	}
+	return start, end, strconv.Itoa(end), nil
+}
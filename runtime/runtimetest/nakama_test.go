@@ -0,0 +1,409 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+const (
+	aliceID = "4c2ae592-b2a7-445e-98ec-697694478b1c"
+	bobID   = "8a3f6d4e-0f5c-4b1d-9a51-2c7e8d9b0a13"
+)
+
+func TestStorageWriteVersion(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+
+	acks, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection: "saves", Key: "slot1", UserID: aliceID, Value: `{"level":1}`, Version: "*",
+	}})
+	if err != nil {
+		t.Fatalf("first write failed: %v", err)
+	}
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection: "saves", Key: "slot1", UserID: aliceID, Value: `{"level":2}`, Version: "*",
+	}}); !errors.Is(err, runtime.ErrStorageRejectedVersion) {
+		t.Fatalf("expected if-not-exists write to be rejected, got %v", err)
+	}
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{Collection: "saves", Key: "slot2", UserID: aliceID, Value: `{}`},
+		{Collection: "saves", Key: "slot1", UserID: aliceID, Value: `{"level":2}`, Version: "stale"},
+	}); !errors.Is(err, runtime.ErrStorageRejectedVersion) {
+		t.Fatalf("expected stale version write to be rejected, got %v", err)
+	}
+	objects, _ := nk.StorageRead(ctx, []*runtime.StorageRead{{Collection: "saves", Key: "slot2", UserID: aliceID}})
+	if len(objects) != 0 {
+		t.Fatalf("expected rejected batch to write nothing, found %v objects", len(objects))
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection: "saves", Key: "slot1", UserID: aliceID, Value: `{"level":2}`, Version: acks[0].Version,
+	}}); err != nil {
+		t.Fatalf("expected matching version write to succeed, got %v", err)
+	}
+	if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+		Collection: "saves", Key: "slot1", UserID: aliceID, Version: acks[0].Version,
+	}}); !errors.Is(err, runtime.ErrStorageRejectedVersion) {
+		t.Fatalf("expected delete with old version to be rejected, got %v", err)
+	}
+}
+
+func TestStorageListPermissions(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{
+		{Collection: "c", Key: "public", UserID: aliceID, Value: `{}`, PermissionRead: runtime.STORAGE_PERMISSION_PUBLIC_READ},
+		{Collection: "c", Key: "owner", UserID: aliceID, Value: `{}`, PermissionRead: runtime.STORAGE_PERMISSION_OWNER_READ},
+		{Collection: "c", Key: "hidden", UserID: aliceID, Value: `{}`, PermissionRead: runtime.STORAGE_PERMISSION_NO_READ},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for callerID, expected := range map[string]int{"": 3, aliceID: 2, bobID: 1} {
+		objects, _, err := nk.StorageList(ctx, callerID, aliceID, "c", 10, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(objects) != expected {
+			t.Errorf("caller %q: expected %v objects, got %v", callerID, expected, len(objects))
+		}
+	}
+}
+
+func TestWalletUpdate(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	nk.AddUser(aliceID, "alice")
+
+	if _, _, err := nk.WalletUpdate(ctx, aliceID, map[string]int64{"coins": 10}, map[string]interface{}{"reason": "reward"}, true); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := nk.WalletUpdate(ctx, aliceID, map[string]int64{"coins": -15}, nil, true)
+	var negative *runtime.WalletNegativeError
+	if !errors.As(err, &negative) || negative.Path != "coins" || negative.Current != 10 || negative.Amount != -15 {
+		t.Fatalf("expected negative wallet error, got %v", err)
+	}
+
+	updated, previous, err := nk.WalletUpdate(ctx, aliceID, map[string]int64{"coins": -4}, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous["coins"] != 10 || updated["coins"] != 6 {
+		t.Fatalf("unexpected wallet values, previous %v updated %v", previous, updated)
+	}
+
+	items, cursor, err := nk.WalletLedgerList(ctx, aliceID, 1, "")
+	if err != nil || len(items) != 1 || cursor == "" {
+		t.Fatalf("expected first ledger page with cursor, got %v items, cursor %q, error %v", len(items), cursor, err)
+	}
+	if items[0].GetMetadata()["reason"] != "reward" {
+		t.Errorf("unexpected ledger metadata %v", items[0].GetMetadata())
+	}
+	if items, _, _ = nk.WalletLedgerList(ctx, aliceID, 10, cursor); len(items) != 1 {
+		t.Errorf("expected second ledger page with 1 item, got %v", len(items))
+	}
+	if _, _, err = nk.WalletLedgerList(ctx, aliceID, 10, "invalid"); !errors.Is(err, runtime.ErrWalletLedgerInvalidCursor) {
+		t.Errorf("expected invalid cursor error, got %v", err)
+	}
+}
+
+func TestFriendsAdd(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	nk.AddUser(aliceID, "alice")
+	nk.AddUser(bobID, "bob")
+
+	if err := nk.FriendsAdd(ctx, aliceID, "alice", nil, []string{"bob"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	received := 2
+	friends, _, _ := nk.FriendsList(ctx, bobID, 10, &received, "")
+	if len(friends) != 1 || friends[0].User.Id != aliceID {
+		t.Fatalf("expected bob to have an invite from alice, got %v", friends)
+	}
+	if notifications := nk.SentNotifications(bobID); len(notifications) != 1 || notifications[0].Code != notificationCodeFriendRequest {
+		t.Fatalf("expected a friend request notification, got %v", notifications)
+	}
+
+	if err := nk.FriendsAdd(ctx, bobID, "bob", []string{aliceID}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	friend := 0
+	friends, _, _ = nk.FriendsList(ctx, aliceID, 10, &friend, "")
+	if len(friends) != 1 {
+		t.Fatalf("expected alice and bob to be friends, got %v", friends)
+	}
+}
+
+func TestGroupMembership(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	nk.AddUser(aliceID, "alice")
+	nk.AddUser(bobID, "bob")
+
+	group, err := nk.GroupCreate(ctx, aliceID, "guild", "", "en", "", "", true, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nk.GroupCreate(ctx, bobID, "guild", "", "en", "", "", true, nil, 1); !errors.Is(err, runtime.ErrGroupNameInUse) {
+		t.Fatalf("expected name in use error, got %v", err)
+	}
+	if err := nk.GroupUserJoin(ctx, group.Id, bobID, "bob"); !errors.Is(err, runtime.ErrGroupFull) {
+		t.Fatalf("expected group full error, got %v", err)
+	}
+	if err := nk.GroupUserLeave(ctx, group.Id, aliceID, "alice"); !errors.Is(err, runtime.ErrGroupLastSuperadmin) {
+		t.Fatalf("expected last superadmin error, got %v", err)
+	}
+	if err := nk.GroupUpdate(ctx, group.Id, "", "", "", "", "", "", true, nil, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := nk.GroupUserJoin(ctx, group.Id, bobID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := nk.GroupUsersKick(ctx, bobID, group.Id, []string{aliceID}); !errors.Is(err, runtime.ErrGroupPermissionDenied) {
+		t.Fatalf("expected member to be denied kicking, got %v", err)
+	}
+	if err := nk.GroupUsersPromote(ctx, aliceID, group.Id, []string{bobID}); err != nil {
+		t.Fatal(err)
+	}
+	users, _, _ := nk.GroupUsersList(ctx, group.Id, 10, nil, "")
+	if len(users) != 2 || users[1].State.GetValue() != groupStateAdmin {
+		t.Fatalf("expected bob to be promoted to admin, got %v", users)
+	}
+}
+
+func TestLeaderboardRecordWrite(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+
+	if err := nk.LeaderboardCreate(ctx, "weekly", true, "desc", "best", "", nil, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nk.LeaderboardRecordWrite(ctx, "weekly", aliceID, "alice", 50, 0, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nk.LeaderboardRecordWrite(ctx, "weekly", bobID, "bob", 80, 0, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	record, err := nk.LeaderboardRecordWrite(ctx, "weekly", aliceID, "alice", 40, 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Score != 50 || record.NumScore != 2 || record.Rank != 2 {
+		t.Fatalf("expected best score 50 at rank 2 after 2 submissions, got %v", record)
+	}
+
+	records, ownerRecords, _, _, err := nk.LeaderboardRecordsList(ctx, "weekly", []string{aliceID}, 10, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].OwnerId != bobID || len(ownerRecords) != 1 {
+		t.Fatalf("unexpected records %v and owner records %v", records, ownerRecords)
+	}
+	if _, err := nk.LeaderboardRecordWrite(ctx, "missing", aliceID, "alice", 1, 0, nil, nil); !errors.Is(err, runtime.ErrLeaderboardNotFound) {
+		t.Fatalf("expected leaderboard not found error, got %v", err)
+	}
+}
+
+func TestMatchmakerAdd(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	sessionID := generateID()
+
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "+properties.region:", 2, 4, 1, nil, nil, nil, 0, nil); !errors.Is(err, runtime.ErrMatchmakerQueryInvalid) {
+		t.Fatalf("expected invalid query error, got %v", err)
+	}
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "*", 2, 5, 2, nil, nil, nil, 0, nil); runtime.ErrorCodeOf(err) != runtime.ErrorCodeInvalidArgument {
+		t.Fatalf("expected invalid count multiple error, got %v", err)
+	}
+	var tickets []string
+	for range nk.MatchmakerMaxTickets {
+		ticket, err := nk.MatchmakerAdd(ctx, sessionID, "+properties.region:eu", 2, 4, 2, map[string]string{"region": "eu"}, nil, nil, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "*", 2, 2, 1, nil, nil, nil, 0, nil); !errors.Is(err, runtime.ErrMatchmakerTooManyTickets) {
+		t.Fatalf("expected too many tickets error, got %v", err)
+	}
+	partyTicket, err := nk.MatchmakerPartyAdd(ctx, "party", "", 2, 2, 0, nil, map[string]float64{"rank": 10}, nil, 0, map[string]float32{"eu": 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nk.MatchmakerRemove(ctx, tickets[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := nk.MatchmakerRemove(ctx, tickets[0]); !errors.Is(err, runtime.ErrMatchmakerTicketNotFound) {
+		t.Fatalf("expected ticket not found error, got %v", err)
+	}
+	list, err := nk.MatchmakerTicketList(ctx, sessionID, "party")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[0].Ticket != tickets[1] || list[0].StringProperties["region"] != "eu" || list[2].Ticket != partyTicket || list[2].Query != "*" || list[2].CountMultiple != 1 || list[2].Latencies["eu"] != 25 {
+		t.Fatalf("unexpected tickets %v", list)
+	}
+}
+
+func TestMatchmakerQueryStages(t *testing.T) {
+	ctx := context.Background()
+	nk := NewNakamaModule()
+	now := time.Unix(1_700_000_000, 0)
+	nk.Now = func() time.Time { return now }
+	sessionID := generateID()
+
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "*", 2, 2, 1, nil, nil, []runtime.MatchmakerQueryStage{{Query: "*", After: 30 * time.Second}, {Query: "*", After: 10 * time.Second}}, 0, nil); !errors.Is(err, runtime.ErrMatchmakerStagesInvalid) {
+		t.Fatalf("expected invalid stages error, got %v", err)
+	}
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "*", 2, 2, 1, nil, nil, []runtime.MatchmakerQueryStage{{Query: "+properties.region:", After: time.Second}}, 0, nil); !errors.Is(err, runtime.ErrMatchmakerQueryInvalid) {
+		t.Fatalf("expected invalid stage query error, got %v", err)
+	}
+	stages := []runtime.MatchmakerQueryStage{
+		{Query: "+properties.region:eu properties.mode:ranked", After: 10 * time.Second},
+		{Query: "*", After: 30 * time.Second},
+	}
+	ticket, err := nk.MatchmakerAdd(ctx, sessionID, "+properties.region:eu +properties.mode:ranked", 2, 2, 1, nil, nil, stages, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range []struct {
+		wait  time.Duration
+		stage int
+		query string
+	}{
+		{0, 0, "+properties.region:eu +properties.mode:ranked"},
+		{15 * time.Second, 1, stages[0].Query},
+		{30 * time.Second, 2, "*"},
+	} {
+		now = time.Unix(1_700_000_000, 0).Add(step.wait)
+		tickets, err := nk.MatchmakerTicketList(ctx, sessionID, "")
+		if err != nil || len(tickets) != 1 || tickets[0].Ticket != ticket {
+			t.Fatalf("expected ticket after %v, got %v, %v", step.wait, tickets, err)
+		}
+		if stage, query := tickets[0].StageAt(now); tickets[0].Stage != step.stage || stage != step.stage || query != step.query {
+			t.Errorf("after %v: expected stage %v with query %q, got %v with %q", step.wait, step.stage, step.query, stage, query)
+		}
+	}
+
+	now = time.Unix(1_700_000_000, 0).Add(time.Minute)
+	if tickets, _ := nk.MatchmakerTicketList(ctx, sessionID, ""); len(tickets) != 0 {
+		t.Fatalf("expected ticket to expire, got %v", tickets)
+	}
+	if err := nk.MatchmakerRemove(ctx, ticket); !errors.Is(err, runtime.ErrMatchmakerTicketNotFound) {
+		t.Fatalf("expected expired ticket not found error, got %v", err)
+	}
+
+	// TTLs are exact rather than rounded to the second.
+	now = time.Unix(1_700_000_100, int64(700*time.Millisecond))
+	if _, err := nk.MatchmakerAdd(ctx, sessionID, "*", 2, 2, 1, nil, nil, nil, 500*time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	if tickets, _ := nk.MatchmakerTicketList(ctx, sessionID, ""); len(tickets) != 1 {
+		t.Fatalf("expected a sub-second TTL not to expire the ticket immediately, got %v", tickets)
+	}
+	now = now.Add(400 * time.Millisecond)
+	if tickets, _ := nk.MatchmakerTicketList(ctx, sessionID, ""); len(tickets) != 1 {
+		t.Fatalf("expected the ticket to be kept until its TTL has passed, got %v", tickets)
+	}
+	now = now.Add(100 * time.Millisecond)
+	if tickets, _ := nk.MatchmakerTicketList(ctx, sessionID, ""); len(tickets) != 0 {
+		t.Fatalf("expected the ticket to expire once its TTL has passed, got %v", tickets)
+	}
+}
+
+func TestRatingsUpdate(t *testing.T) {
+	nk := NewNakamaModule()
+	nk.AddUser(aliceID, "alice")
+	nk.AddUser(bobID, "bob")
+	now := time.Unix(1_700_000_000, 0)
+	nk.Now = func() time.Time { return now }
+	nk.AddRatingRuleset(&runtime.RatingRuleset{ID: "duel", DecayPeriod: 24 * time.Hour, MatchmakerProperty: "skill"})
+	ctx := runtime.NewContext(context.Background(), &runtime.RuntimeContext{
+		UserID:    runtime.ContextValueOf(aliceID),
+		SessionID: runtime.ContextValueOf("session"),
+	})
+
+	if _, err := nk.RatingGet(ctx, "missing", aliceID); !errors.Is(err, runtime.ErrRatingRulesetNotFound) {
+		t.Fatalf("expected ruleset not found error, got %v", err)
+	}
+	if _, err := nk.RatingsUpdate(ctx, "duel", [][]string{{aliceID}}, []int{1}, nil); !errors.Is(err, runtime.ErrRatingUpdateInvalid) {
+		t.Fatalf("expected invalid update error, got %v", err)
+	}
+	if _, err := nk.RatingsUpdate(ctx, "duel", [][]string{{aliceID}, {bobID, aliceID}}, []int{1, 2}, nil); !errors.Is(err, runtime.ErrRatingUpdateInvalid) {
+		t.Fatalf("expected invalid update error for a duplicate user, got %v", err)
+	}
+	updated, err := nk.RatingsUpdate(ctx, "duel", [][]string{{aliceID}, {bobID}}, []int{1, 2}, map[string]interface{}{"match": "m1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := updated[0][0]
+	if alice.Rating <= runtime.DefaultRating || updated[1][0].Rating >= runtime.DefaultRating || alice.Matches != 1 || alice.UpdateTime != now.Unix() {
+		t.Fatalf("unexpected ratings %+v %+v", alice, updated[1][0])
+	}
+
+	items, _, err := nk.RatingLedgerList(ctx, "duel", aliceID, 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Change != alice.Rating-runtime.DefaultRating || items[0].Metadata["match"] != "m1" {
+		t.Fatalf("unexpected ledger items %v", items)
+	}
+
+	now = now.Add(30 * 24 * time.Hour)
+	decayed, err := nk.RatingGet(ctx, "duel", aliceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decayed.Rating != alice.Rating || decayed.Deviation <= alice.Deviation {
+		t.Fatalf("expected decayed deviation, got %+v", decayed)
+	}
+
+	// The duel ruleset comes first by ID, so its rating sets the property both rulesets use.
+	nk.AddRatingRuleset(&runtime.RatingRuleset{ID: "zone", InitialRating: 2000, MatchmakerProperty: "skill"})
+	if _, err := nk.MatchmakerAdd(ctx, "session", "*", 2, 2, 1, nil, nil, nil, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nk.MatchmakerAdd(ctx, "other", "*", 2, 2, 1, nil, nil, nil, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	tickets, err := nk.MatchmakerTicketList(ctx, "session", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || tickets[0].NumericProperties["skill"] != alice.Rating {
+		t.Fatalf("expected rating matchmaker property, got %v", tickets)
+	}
+	if tickets, _ = nk.MatchmakerTicketList(ctx, "other", ""); len(tickets) != 1 || tickets[0].NumericProperties != nil {
+		t.Fatalf("expected no rating matchmaker property for another session, got %v", tickets)
+	}
+
+	nk.AddPartyMembers("party", aliceID, bobID)
+	if _, err := nk.MatchmakerPartyAdd(ctx, "party", "*", 2, 4, 1, nil, nil, nil, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if tickets, _ = nk.MatchmakerTicketList(ctx, "", "party"); len(tickets) != 1 || tickets[0].MemberNumericProperties[aliceID]["skill"] != alice.Rating || tickets[0].MemberNumericProperties[bobID]["skill"] != updated[1][0].Rating {
+		t.Fatalf("expected rating matchmaker properties for each party member, got %v", tickets)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type sentNotification struct {
+	userID       string
+	notification *api.Notification
+}
+
+// SentNotifications returns every notification delivered to a user, including ones that were not persisted.
+func (n *NakamaModule) SentNotifications(userID string) []*api.Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	notifications := make([]*api.Notification, 0)
+	for _, sent := range n.sent {
+		if sent.userID == userID {
+			notifications = append(notifications, proto.Clone(sent.notification).(*api.Notification))
+		}
+	}
+	return notifications
+}
+
+func (n *NakamaModule) NotificationSend(ctx context.Context, userID, subject string, content map[string]interface{}, code int, sender string, persistent bool) error {
+	return n.NotificationsSend(ctx, []*runtime.NotificationSend{{
+		UserID:     userID,
+		Subject:    subject,
+		Content:    content,
+		Code:       code,
+		Sender:     sender,
+		Persistent: persistent,
+	}})
+}
+
+func (n *NakamaModule) NotificationsSend(ctx context.Context, notifications []*runtime.NotificationSend) error {
+	pending := make([]*sentNotification, 0, len(notifications))
+	now := timestamppb.New(n.Now())
+	for _, notification := range notifications {
+		if notification.UserID == "" {
+			return errors.New("expects userID to be a valid UUID")
+		}
+		if notification.Subject == "" {
+			return errors.New("expects subject to be a non-empty string")
+		}
+		if notification.Code <= 0 {
+			return errors.New("expects code to number above 0")
+		}
+		content, err := json.Marshal(notification.Content)
+		if err != nil {
+			return errors.New("failed to convert content")
+		}
+		pending = append(pending, &sentNotification{
+			userID: notification.UserID,
+			notification: &api.Notification{
+				Id:         generateID(),
+				Subject:    notification.Subject,
+				Content:    string(content),
+				Code:       int32(notification.Code),
+				SenderId:   notification.Sender,
+				CreateTime: now,
+				Persistent: notification.Persistent,
+			},
+		})
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.deliverLocked(pending)
+	return nil
+}
+
+func (n *NakamaModule) deliverLocked(pending []*sentNotification) {
+	for _, sent := range pending {
+		n.sent = append(n.sent, sent)
+		if sent.notification.Persistent {
+			n.notifications[sent.userID] = append(n.notifications[sent.userID], sent.notification)
+		}
+	}
+}
+
+func (n *NakamaModule) NotificationSendAll(ctx context.Context, subject string, content map[string]interface{}, code int, persistent bool) error {
+	n.mu.Lock()
+	userIDs := make([]string, 0, len(n.accounts))
+	for userID := range n.accounts {
+		userIDs = append(userIDs, userID)
+	}
+	n.mu.Unlock()
+
+	slices.Sort(userIDs)
+	notifications := make([]*runtime.NotificationSend, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, &runtime.NotificationSend{
+			UserID:     userID,
+			Subject:    subject,
+			Content:    content,
+			Code:       code,
+			Persistent: persistent,
+		})
+	}
+	return n.NotificationsSend(ctx, notifications)
+}
+
+// NotificationsList returns the persistent notifications of a user in the order they were sent.
+func (n *NakamaModule) NotificationsList(ctx context.Context, userID string, limit int, cursor string) ([]*api.Notification, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	notifications := n.notifications[userID]
+	start, end, next, err := paginate(cursor, limit, len(notifications))
+	if err != nil {
+		return nil, "", err
+	}
+	page := make([]*api.Notification, 0, end-start)
+	for _, notification := range notifications[start:end] {
+		page = append(page, proto.Clone(notification).(*api.Notification))
+	}
+	return page, next, nil
+}
+
+func (n *NakamaModule) NotificationsUpdate(ctx context.Context, updates ...runtime.NotificationUpdate) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, update := range updates {
+		var content []byte
+		if update.Content != nil {
+			var err error
+			if content, err = json.Marshal(update.Content); err != nil {
+				return errors.New("failed to convert content")
+			}
+		}
+		for _, notifications := range n.notifications {
+			for _, notification := range notifications {
+				if notification.Id != update.Id {
+					continue
+				}
+				if update.Subject != nil {
+					notification.Subject = *update.Subject
+				}
+				if content != nil {
+					notification.Content = string(content)
+				}
+				if update.Sender != nil {
+					notification.SenderId = *update.Sender
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (n *NakamaModule) NotificationsDelete(ctx context.Context, notifications []*runtime.NotificationDelete) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, del := range notifications {
+		n.deleteNotificationsLocked(del.UserID, []string{del.NotificationID})
+	}
+	return nil
+}
+
+func (n *NakamaModule) NotificationsDeleteId(ctx context.Context, userID string, ids []string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.deleteNotificationsLocked(userID, ids)
+	return nil
+}
+
+func (n *NakamaModule) deleteNotificationsLocked(userID string, ids []string) {
+	n.notifications[userID] = slices.DeleteFunc(n.notifications[userID], func(notification *api.Notification) bool {
+		return slices.Contains(ids, notification.Id)
+	})
+}
+
+func (n *NakamaModule) NotificationsGetId(ctx context.Context, userID string, ids []string) ([]*runtime.Notification, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	notifications := make([]*runtime.Notification, 0, len(ids))
+	for _, notification := range n.notifications[userID] {
+		if !slices.Contains(ids, notification.Id) {
+			continue
+		}
+		var content map[string]any
+		_ = json.Unmarshal([]byte(notification.Content), &content)
+		notifications = append(notifications, &runtime.Notification{
+			Id:         notification.Id,
+			UserID:     userID,
+			Subject:    notification.Subject,
+			Content:    content,
+			Code:       int(notification.Code),
+			Sender:     notification.SenderId,
+			CreateTime: notification.CreateTime,
+			Persistent: notification.Persistent,
+		})
+	}
+	return notifications, nil
+}
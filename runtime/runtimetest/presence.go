@@ -0,0 +1,152 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// DefaultNode is the node name used for presences and matches when none is given.
+const DefaultNode = "nakama"
+
+var (
+	_ runtime.Presence          = (*Presence)(nil)
+	_ runtime.PresenceSpectator = (*Presence)(nil)
+	_ runtime.MatchData         = (*MatchData)(nil)
+)
+
+// Presence is a runtime.Presence with exported fields.
+type Presence struct {
+	UserID      string
+	SessionID   string
+	NodeID      string
+	Username    string
+	Status      string
+	Hidden      bool
+	Persistence bool
+	Reason      runtime.PresenceReason
+	Spectator   bool
+}
+
+// NewPresence returns the presence of a user connected to the default node with a new session.
+func NewPresence(userID, username string) *Presence {
+	return &Presence{
+		UserID:      userID,
+		SessionID:   generateID(),
+		NodeID:      DefaultNode,
+		Username:    username,
+		Persistence: true,
+	}
+}
+
+// NewSpectator returns the presence of a user connected to the default node with a new session, joining matches as
+// a spectator.
+func NewSpectator(userID, username string) *Presence {
+	p := NewPresence(userID, username)
+	p.Spectator = true
+	return p
+}
+
+// presenceOf returns a copy of the presence.
+func presenceOf(presence runtime.Presence) *Presence {
+	return &Presence{
+		UserID:      presence.GetUserId(),
+		SessionID:   presence.GetSessionId(),
+		NodeID:      presence.GetNodeId(),
+		Username:    presence.GetUsername(),
+		Status:      presence.GetStatus(),
+		Hidden:      presence.GetHidden(),
+		Persistence: presence.GetPersistence(),
+		Reason:      presence.GetReason(),
+		Spectator:   spectating(presence),
+	}
+}
+
+func (p *Presence) GetUserId() string {
+	return p.UserID
+}
+
+func (p *Presence) GetSessionId() string {
+	return p.SessionID
+}
+
+func (p *Presence) GetNodeId() string {
+	return p.NodeID
+}
+
+func (p *Presence) GetHidden() bool {
+	return p.Hidden
+}
+
+func (p *Presence) GetPersistence() bool {
+	return p.Persistence
+}
+
+func (p *Presence) GetUsername() string {
+	return p.Username
+}
+
+func (p *Presence) GetStatus() string {
+	return p.Status
+}
+
+func (p *Presence) GetReason() runtime.PresenceReason {
+	return p.Reason
+}
+
+func (p *Presence) GetSpectator() bool {
+	return p.Spectator
+}
+
+// spectating returns true if the presence reports through runtime.PresenceSpectator that it joined as a spectator.
+func spectating(presence runtime.Presence) bool {
+	spectator, ok := presence.(runtime.PresenceSpectator)
+	return ok && spectator.GetSpectator()
+}
+
+// MatchData is a runtime.MatchData with exported fields. ReceiveTime is in milliseconds since the Unix epoch.
+type MatchData struct {
+	Presence
+	OpCode      int64
+	Data        []byte
+	Reliable    bool
+	ReceiveTime int64
+	Sequence    int64
+	Ack         int64
+}
+
+func (d *MatchData) GetOpCode() int64 {
+	return d.OpCode
+}
+
+func (d *MatchData) GetData() []byte {
+	return d.Data
+}
+
+func (d *MatchData) GetReliable() bool {
+	return d.Reliable
+}
+
+func (d *MatchData) GetReceiveTime() int64 {
+	return d.ReceiveTime
+}
+
+func (d *MatchData) GetSequence() int64 {
+	return d.Sequence
+}
+
+func (d *MatchData) GetAck() int64 {
+	return d.Ack
+}
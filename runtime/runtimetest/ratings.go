@@ -0,0 +1,160 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+type ratingKey struct {
+	ruleset string
+	userID  string
+}
+
+// AddRatingRuleset adds a rating ruleset, replacing any ruleset with the same ID. An Initializer adds the rulesets
+// registered with it when its NK is this module.
+func (n *NakamaModule) AddRatingRuleset(ruleset *runtime.RatingRuleset) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	r := *ruleset
+	n.rulesets[r.ID] = &r
+}
+
+// ratingLocked returns the current rating of the user in the ruleset, decayed to the current time.
+func (n *NakamaModule) ratingLocked(ruleset *runtime.RatingRuleset, userID string) *runtime.Rating {
+	rating, ok := n.ratings[ratingKey{ruleset: ruleset.ID, userID: userID}]
+	if !ok {
+		return runtime.NewRating(ruleset, userID)
+	}
+	return runtime.DecayRating(ruleset, rating, n.Now())
+}
+
+func (n *NakamaModule) RatingGet(ctx context.Context, ruleset, userID string) (*runtime.Rating, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	r, ok := n.rulesets[ruleset]
+	if !ok {
+		return nil, runtime.ErrRatingRulesetNotFound
+	}
+	if _, ok := n.accounts[userID]; !ok {
+		return nil, errAccountNotFound
+	}
+	return n.ratingLocked(r, userID), nil
+}
+
+func (n *NakamaModule) RatingsUpdate(ctx context.Context, ruleset string, teams [][]string, ranks []int, metadata map[string]interface{}) ([][]*runtime.Rating, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	r, ok := n.rulesets[ruleset]
+	if !ok {
+		return nil, runtime.ErrRatingRulesetNotFound
+	}
+	before := make([][]*runtime.Rating, 0, len(teams))
+	userIDs := make(map[string]struct{})
+	for _, team := range teams {
+		ratings := make([]*runtime.Rating, 0, len(team))
+		for _, userID := range team {
+			if _, ok := userIDs[userID]; ok {
+				return nil, runtime.ErrRatingUpdateInvalid
+			}
+			userIDs[userID] = struct{}{}
+			if _, ok := n.accounts[userID]; !ok {
+				return nil, errAccountNotFound
+			}
+			ratings = append(ratings, n.ratingLocked(r, userID))
+		}
+		before = append(before, ratings)
+	}
+	after, err := runtime.UpdateRatings(r, before, ranks)
+	if err != nil {
+		return nil, err
+	}
+
+	now := n.Now().Unix()
+	for i, team := range after {
+		for j, rating := range team {
+			rating.UpdateTime = now
+			updated := *rating
+			n.ratings[ratingKey{ruleset: ruleset, userID: rating.UserID}] = &updated
+			n.ratingLedger = append(n.ratingLedger, &runtime.RatingLedgerItem{
+				ID:         generateID(),
+				UserID:     rating.UserID,
+				Ruleset:    ruleset,
+				Rating:     rating.Rating,
+				Deviation:  rating.Deviation,
+				Volatility: rating.Volatility,
+				Change:     rating.Rating - before[i][j].Rating,
+				Metadata:   maps.Clone(metadata),
+				CreateTime: now,
+			})
+		}
+	}
+	return after, nil
+}
+
+func (n *NakamaModule) RatingLedgerList(ctx context.Context, ruleset, userID string, limit int, cursor string) ([]*runtime.RatingLedgerItem, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.rulesets[ruleset]; !ok {
+		return nil, "", runtime.ErrRatingRulesetNotFound
+	}
+	items := make([]*runtime.RatingLedgerItem, 0)
+	for _, item := range n.ratingLedger {
+		if item.Ruleset == ruleset && item.UserID == userID {
+			items = append(items, item)
+		}
+	}
+
+	start, end, next, err := paginate(cursor, limit, len(items))
+	if err != nil {
+		return nil, "", err
+	}
+	page := make([]*runtime.RatingLedgerItem, 0, end-start)
+	for _, item := range items[start:end] {
+		i := *item
+		i.Metadata = maps.Clone(item.Metadata)
+		page = append(page, &i)
+	}
+	return page, next, nil
+}
+
+// ratingPropertiesLocked returns the ratings of the user as matchmaker properties, for the rulesets that set a
+// matchmaker property the ticket does not already set. Rulesets are applied in order of ID, so that when several set
+// the same property the first one sets it.
+func (n *NakamaModule) ratingPropertiesLocked(userID string, numericProperties map[string]float64) map[string]float64 {
+	ratings := make(map[string]float64)
+	for _, id := range slices.Sorted(maps.Keys(n.rulesets)) {
+		ruleset := n.rulesets[id]
+		if ruleset.MatchmakerProperty == "" {
+			continue
+		}
+		if _, ok := numericProperties[ruleset.MatchmakerProperty]; ok {
+			continue
+		}
+		if _, ok := ratings[ruleset.MatchmakerProperty]; ok {
+			continue
+		}
+		ratings[ruleset.MatchmakerProperty] = n.ratingLocked(ruleset, userID).Rating
+	}
+	return ratings
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type storageKey struct {
+	collection string
+	key        string
+	userID     string
+}
+
+func newStorageKey(collection, key, userID string) storageKey {
+	if userID == "" {
+		userID = SystemUserID
+	}
+	return storageKey{collection: collection, key: key, userID: userID}
+}
+
+func validateStorageKey(collection, key string) error {
+	if collection == "" {
+		return errors.New("expects collection to be a non-empty string")
+	}
+	if key == "" {
+		return errors.New("expects key to be a non-empty string")
+	}
+	return nil
+}
+
+func (n *NakamaModule) StorageRead(ctx context.Context, reads []*runtime.StorageRead) ([]*api.StorageObject, error) {
+	for _, read := range reads {
+		if err := validateStorageKey(read.Collection, read.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	objects := make([]*api.StorageObject, 0, len(reads))
+	for _, read := range reads {
+		if object, ok := n.storage[newStorageKey(read.Collection, read.Key, read.UserID)]; ok {
+			objects = append(objects, proto.Clone(object).(*api.StorageObject))
+		}
+	}
+	return objects, nil
+}
+
+func (n *NakamaModule) StorageWrite(ctx context.Context, writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	acks, commit, err := n.prepareStorageWritesLocked(writes)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+	return acks, nil
+}
+
+// prepareStorageWritesLocked validates a batch of writes against the current state and returns a function that
+// applies them. Nothing is modified if any write in the batch is rejected.
+func (n *NakamaModule) prepareStorageWritesLocked(writes []*runtime.StorageWrite) ([]*api.StorageObjectAck, func(), error) {
+	now := timestamppb.New(n.Now())
+	pending := make(map[storageKey]*api.StorageObject, len(writes))
+	order := make([]storageKey, 0, len(writes))
+	acks := make([]*api.StorageObjectAck, 0, len(writes))
+
+	for _, write := range writes {
+		if err := validateStorageKey(write.Collection, write.Key); err != nil {
+			return nil, nil, err
+		}
+		if write.PermissionRead < runtime.STORAGE_PERMISSION_NO_READ || write.PermissionRead > runtime.STORAGE_PERMISSION_PUBLIC_READ {
+			return nil, nil, errors.New("expects read permission to be 0, 1, or 2")
+		}
+		if write.PermissionWrite < runtime.STORAGE_PERMISSION_NO_WRITE || write.PermissionWrite > runtime.STORAGE_PERMISSION_OWNER_WRITE {
+			return nil, nil, errors.New("expects write permission to be 0 or 1")
+		}
+		var value map[string]interface{}
+		if err := json.Unmarshal([]byte(write.Value), &value); err != nil || value == nil {
+			return nil, nil, errors.New("value must be a JSON object")
+		}
+
+		key := newStorageKey(write.Collection, write.Key, write.UserID)
+		existing, ok := pending[key]
+		if !ok {
+			existing = n.storage[key]
+		}
+		switch {
+		case write.Version == "":
+		case write.Version == "*":
+			if existing != nil {
+				return nil, nil, runtime.ErrStorageRejectedVersion
+			}
+		default:
+			if existing == nil || existing.Version != write.Version {
+				return nil, nil, runtime.ErrStorageRejectedVersion
+			}
+		}
+
+		object := &api.StorageObject{
+			Collection:      write.Collection,
+			Key:             write.Key,
+			UserId:          key.userID,
+			Value:           write.Value,
+			Version:         fmt.Sprintf("%x", md5.Sum([]byte(write.Value))),
+			PermissionRead:  int32(write.PermissionRead),
+			PermissionWrite: int32(write.PermissionWrite),
+			CreateTime:      now,
+			UpdateTime:      now,
+		}
+		if existing != nil {
+			object.CreateTime = existing.CreateTime
+		}
+		if _, ok := pending[key]; !ok {
+			order = append(order, key)
+		}
+		pending[key] = object
+		acks = append(acks, &api.StorageObjectAck{
+			Collection: object.Collection,
+			Key:        object.Key,
+			Version:    object.Version,
+			UserId:     object.UserId,
+			CreateTime: object.CreateTime,
+			UpdateTime: object.UpdateTime,
+		})
+	}
+
+	return acks, func() {
+		for _, key := range order {
+			n.storage[key] = pending[key]
+		}
+	}, nil
+}
+
+func (n *NakamaModule) StorageDelete(ctx context.Context, deletes []*runtime.StorageDelete) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	commit, err := n.prepareStorageDeletesLocked(deletes)
+	if err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+func (n *NakamaModule) prepareStorageDeletesLocked(deletes []*runtime.StorageDelete) (func(), error) {
+	keys := make([]storageKey, 0, len(deletes))
+	for _, del := range deletes {
+		if err := validateStorageKey(del.Collection, del.Key); err != nil {
+			return nil, err
+		}
+		key := newStorageKey(del.Collection, del.Key, del.UserID)
+		if del.Version != "" {
+			if existing, ok := n.storage[key]; !ok || existing.Version != del.Version {
+				return nil, runtime.ErrStorageRejectedVersion
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	return func() {
+		for _, key := range keys {
+			delete(n.storage, key)
+		}
+	}, nil
+}
+
+// StorageList returns the objects in a collection visible to callerID. An empty callerID lists as the server,
+// and an empty userID lists objects across all owners.
+func (n *NakamaModule) StorageList(ctx context.Context, callerID, userID, collection string, limit int, cursor string) ([]*api.StorageObject, string, error) {
+	if collection == "" {
+		return nil, "", errors.New("expects collection to be a non-empty string")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	objects := make([]*api.StorageObject, 0)
+	for key, object := range n.storage {
+		if key.collection != collection || (userID != "" && key.userID != userID) {
+			continue
+		}
+		if !storageReadable(callerID, object) {
+			continue
+		}
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Key != objects[j].Key {
+			return objects[i].Key < objects[j].Key
+		}
+		return objects[i].UserId < objects[j].UserId
+	})
+
+	start, end, next, err := paginate(cursor, limit, len(objects))
+	if err != nil {
+		return nil, "", err
+	}
+	page := make([]*api.StorageObject, 0, end-start)
+	for _, object := range objects[start:end] {
+		page = append(page, proto.Clone(object).(*api.StorageObject))
+	}
+	return page, next, nil
+}
+
+// storageReadable applies the storage read permission rules for a caller. The server may read every object, owners
+// may read their objects unless they have no read permission, and other users may only read public objects.
+func storageReadable(callerID string, object *api.StorageObject) bool {
+	switch {
+	case callerID == "" || callerID == SystemUserID:
+		return true
+	case callerID == object.UserId:
+		return object.PermissionRead >= runtime.STORAGE_PERMISSION_OWNER_READ
+	default:
+		return object.PermissionRead == runtime.STORAGE_PERMISSION_PUBLIC_READ
+	}
+}
+
+// StorageWritable applies the storage write permission rules for a caller. The server may write every object, while
+// users may only write objects they own that have owner write permission. The runtime StorageWrite function always
+// writes as the server, so module code that writes on behalf of a user can use this to enforce client semantics.
+func (n *NakamaModule) StorageWritable(callerID, collection, key, userID string) bool {
+	if callerID == "" || callerID == SystemUserID {
+		return true
+	}
+	if userID == "" || callerID != userID {
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	object, ok := n.storage[newStorageKey(collection, key, userID)]
+	return !ok || object.PermissionWrite == runtime.STORAGE_PERMISSION_OWNER_WRITE
+}
+
+func (n *NakamaModule) MultiUpdate(ctx context.Context, accountUpdates []*runtime.AccountUpdate, storageWrites []*runtime.StorageWrite, storageDeletes []*runtime.StorageDelete, walletUpdates []*runtime.WalletUpdate, updateLedger bool) ([]*api.StorageObjectAck, []*runtime.WalletUpdateResult, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, update := range accountUpdates {
+		if err := n.validateAccountUpdateLocked(update); err != nil {
+			return nil, nil, err
+		}
+	}
+	acks, commitWrites, err := n.prepareStorageWritesLocked(storageWrites)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitDeletes, err := n.prepareStorageDeletesLocked(storageDeletes)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, commitWallets, err := n.prepareWalletUpdatesLocked(walletUpdates, updateLedger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, update := range accountUpdates {
+		if err := n.accountUpdateLocked(update); err != nil {
+			return nil, nil, err
+		}
+	}
+	commitWrites()
+	commitDeletes()
+	commitWallets()
+	return acks, results, nil
+}
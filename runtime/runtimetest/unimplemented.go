@@ -0,0 +1,340 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"os"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// Functions below depend on external services or realtime sessions and have no in-memory implementation.
+
+func (n *NakamaModule) AuthenticateApple(ctx context.Context, token, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateFacebook(ctx context.Context, token string, importFriends bool, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateFacebookInstantGame(ctx context.Context, signedPlayerInfo string, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateGameCenter(ctx context.Context, playerID, bundleID string, timestamp int64, salt, signature, publicKeyUrl, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateGoogle(ctx context.Context, token, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateSteam(ctx context.Context, token, username string, create bool) (string, string, bool, error) {
+	return "", "", false, ErrNotImplemented
+}
+
+func (n *NakamaModule) AuthenticateTokenGenerate(userID, username string, exp int64, vars map[string]string) (string, int64, error) {
+	return "", 0, ErrNotImplemented
+}
+
+func (n *NakamaModule) AccountExportId(ctx context.Context, userID string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+func (n *NakamaModule) UsersGetRandom(ctx context.Context, count int) ([]*api.User, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkApple(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkCustom(ctx context.Context, userID, customID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkDevice(ctx context.Context, userID, deviceID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkEmail(ctx context.Context, userID, email, password string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkFacebook(ctx context.Context, userID, username, token string, importFriends bool) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkFacebookInstantGame(ctx context.Context, userID, signedPlayerInfo string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkGameCenter(ctx context.Context, userID, playerID, bundleID string, timestamp int64, salt, signature, publicKeyUrl string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkGoogle(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) LinkSteam(ctx context.Context, userID, username, token string, importFriends bool) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) CronPrev(expression string, timestamp int64) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (n *NakamaModule) CronNext(expression string, timestamp int64) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (n *NakamaModule) ReadFile(path string) (*os.File, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkApple(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkCustom(ctx context.Context, userID, customID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkDevice(ctx context.Context, userID, deviceID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkEmail(ctx context.Context, userID, email string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkFacebook(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkFacebookInstantGame(ctx context.Context, userID, signedPlayerInfo string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkGameCenter(ctx context.Context, userID, playerID, bundleID string, timestamp int64, salt, signature, publicKeyUrl string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkGoogle(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) UnlinkSteam(ctx context.Context, userID, token string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserList(mode uint8, subject, subcontext, label string, includeHidden, includeNotHidden bool) ([]runtime.Presence, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserGet(mode uint8, subject, subcontext, label, userID, sessionID string) (runtime.PresenceMeta, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserJoin(mode uint8, subject, subcontext, label, userID, sessionID string, hidden, persistence bool, status string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserUpdate(mode uint8, subject, subcontext, label, userID, sessionID string, hidden, persistence bool, status string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserLeave(mode uint8, subject, subcontext, label, userID, sessionID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamUserKick(mode uint8, subject, subcontext, label string, presence runtime.Presence) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamCount(mode uint8, subject, subcontext, label string) (int, error) {
+	return 0, ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamClose(mode uint8, subject, subcontext, label string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamSend(mode uint8, subject, subcontext, label, data string, presences []runtime.Presence, reliable bool) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StreamSendRaw(mode uint8, subject, subcontext, label string, msg *rtapi.Envelope, presences []runtime.Presence, reliable bool) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) SessionDisconnect(ctx context.Context, sessionID string, reason ...runtime.PresenceReason) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) SessionLogout(userID, token, refreshToken string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) MatchCreate(ctx context.Context, module string, params map[string]interface{}) (string, error) {
+	return "", ErrNotImplemented
+}
+
+func (n *NakamaModule) MatchGet(ctx context.Context, id string) (*api.Match, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) MatchSignal(ctx context.Context, id string, data string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+func (n *NakamaModule) StorageIndexList(ctx context.Context, callerID, indexName, query string, limit int, order []string, cursor string) (*api.StorageObjects, string, error) {
+	return nil, "", ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchaseValidateApple(ctx context.Context, userID, receipt string, persist bool, passwordOverride ...string) (*api.ValidatePurchaseResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchaseValidateGoogle(ctx context.Context, userID, receipt string, persist bool, overrides ...struct {
+	ClientEmail string
+	PrivateKey  string
+}) (*api.ValidatePurchaseResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchaseValidateHuawei(ctx context.Context, userID, signature, inAppPurchaseData string, persist bool) (*api.ValidatePurchaseResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchaseValidateFacebookInstant(ctx context.Context, userID, signedRequest string, persist bool) (*api.ValidatePurchaseResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchasesList(ctx context.Context, userID string, limit int, cursor string) (*api.PurchaseList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) PurchaseGetByTransactionId(ctx context.Context, transactionID string) (*api.ValidatedPurchase, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) SubscriptionValidateApple(ctx context.Context, userID, receipt string, persist bool, passwordOverride ...string) (*api.ValidateSubscriptionResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) SubscriptionValidateGoogle(ctx context.Context, userID, receipt string, persist bool, overrides ...struct {
+	ClientEmail string
+	PrivateKey  string
+}) (*api.ValidateSubscriptionResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) SubscriptionsList(ctx context.Context, userID string, limit int, cursor string) (*api.SubscriptionList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) SubscriptionGetByProductId(ctx context.Context, userID, productID string) (*api.ValidatedSubscription, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentCreate(ctx context.Context, id string, authoritative bool, sortOrder, operator, resetSchedule string, metadata map[string]interface{}, title, description string, category, startTime, endTime, duration, maxSize, maxNumScore int, joinRequired, enableRanks bool) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentDelete(ctx context.Context, id string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentAddAttempt(ctx context.Context, id, ownerID string, count int) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentJoin(ctx context.Context, id, ownerID, username string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentsGetId(ctx context.Context, tournamentIDs []string) ([]*api.Tournament, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentList(ctx context.Context, categoryStart, categoryEnd, startTime, endTime, limit int, cursor string) (*api.TournamentList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentRanksDisable(ctx context.Context, id string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentRecordsList(ctx context.Context, tournamentId string, ownerIDs []string, limit int, cursor string, overrideExpiry int64) (records []*api.LeaderboardRecord, ownerRecords []*api.LeaderboardRecord, prevCursor string, nextCursor string, err error) {
+	return nil, nil, "", "", ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentRecordWrite(ctx context.Context, id, ownerID, username string, score, subscore int64, metadata map[string]interface{}, operatorOverride *int) (*api.LeaderboardRecord, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentRecordDelete(ctx context.Context, id, ownerID string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) TournamentRecordsHaystack(ctx context.Context, id, ownerID string, limit int, cursor string, expiry int64) (*api.TournamentRecordList, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) GroupsGetRandom(ctx context.Context, count int) ([]*api.Group, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) FriendsOfFriendsList(ctx context.Context, userID string, limit int, cursor string) ([]*api.FriendsOfFriendsList_FriendOfFriend, string, error) {
+	return nil, "", ErrNotImplemented
+}
+
+func (n *NakamaModule) ChannelIdBuild(ctx context.Context, sender string, target string, chanType runtime.ChannelType) (string, error) {
+	return "", ErrNotImplemented
+}
+
+func (n *NakamaModule) ChannelMessageSend(ctx context.Context, channelID string, content map[string]interface{}, senderId, senderUsername string, persist bool) (*rtapi.ChannelMessageAck, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) ChannelMessageUpdate(ctx context.Context, channelID, messageID string, content map[string]interface{}, senderId, senderUsername string, persist bool) (*rtapi.ChannelMessageAck, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) ChannelMessageRemove(ctx context.Context, channelId, messageId string, senderId, senderUsername string, persist bool) (*rtapi.ChannelMessageAck, error) {
+	return nil, ErrNotImplemented
+}
+
+func (n *NakamaModule) ChannelMessagesList(ctx context.Context, channelId string, limit int, forward bool, cursor string) (messages []*api.ChannelMessage, nextCursor string, prevCursor string, err error) {
+	return nil, "", "", ErrNotImplemented
+}
+
+func (n *NakamaModule) PartyList(ctx context.Context, limit int, open *bool, showHidden bool, query, cursor string) ([]*api.Party, string, error) {
+	return nil, "", ErrNotImplemented
+}
+
+func (n *NakamaModule) StatusFollow(sessionID string, userIDs []string) error {
+	return ErrNotImplemented
+}
+
+func (n *NakamaModule) StatusUnfollow(sessionID string, userIDs []string) error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimetest
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+type walletLedgerItem struct {
+	id         string
+	userID     string
+	createTime int64
+	updateTime int64
+	changeset  map[string]int64
+	metadata   map[string]interface{}
+}
+
+func (i *walletLedgerItem) GetID() string                       { return i.id }
+func (i *walletLedgerItem) GetUserID() string                   { return i.userID }
+func (i *walletLedgerItem) GetCreateTime() int64                { return i.createTime }
+func (i *walletLedgerItem) GetUpdateTime() int64                { return i.updateTime }
+func (i *walletLedgerItem) GetChangeset() map[string]int64      { return i.changeset }
+func (i *walletLedgerItem) GetMetadata() map[string]interface{} { return i.metadata }
+
+func (i *walletLedgerItem) clone() *walletLedgerItem {
+	c := *i
+	c.changeset = maps.Clone(i.changeset)
+	c.metadata = maps.Clone(i.metadata)
+	return &c
+}
+
+func (n *NakamaModule) WalletUpdate(ctx context.Context, userID string, changeset map[string]int64, metadata map[string]interface{}, updateLedger bool) (map[string]int64, map[string]int64, error) {
+	results, err := n.WalletsUpdate(ctx, []*runtime.WalletUpdate{{
+		UserID:    userID,
+		Changeset: changeset,
+		Metadata:  metadata,
+	}}, updateLedger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results[0].Updated, results[0].Previous, nil
+}
+
+func (n *NakamaModule) WalletsUpdate(ctx context.Context, updates []*runtime.WalletUpdate, updateLedger bool) ([]*runtime.WalletUpdateResult, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	results, commit, err := n.prepareWalletUpdatesLocked(updates, updateLedger)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+	return results, nil
+}
+
+// prepareWalletUpdatesLocked computes the result of a batch of wallet updates and returns a function that applies
+// them. Nothing is modified if any update would leave a wallet with a negative value.
+func (n *NakamaModule) prepareWalletUpdatesLocked(updates []*runtime.WalletUpdate, updateLedger bool) ([]*runtime.WalletUpdateResult, func(), error) {
+	wallets := make(map[string]map[string]int64, len(updates))
+	results := make([]*runtime.WalletUpdateResult, 0, len(updates))
+	ledger := make([]*walletLedgerItem, 0, len(updates))
+	now := n.Now().Unix()
+
+	for _, update := range updates {
+		a, ok := n.accounts[update.UserID]
+		if !ok {
+			return nil, nil, errAccountNotFound
+		}
+		wallet, ok := wallets[update.UserID]
+		if !ok {
+			wallet = maps.Clone(a.wallet)
+		}
+
+		previous := maps.Clone(wallet)
+		for path, amount := range update.Changeset {
+			current := wallet[path]
+			if current+amount < 0 {
+				return nil, nil, &runtime.WalletNegativeError{
+					UserID:  update.UserID,
+					Path:    path,
+					Current: current,
+					Amount:  amount,
+				}
+			}
+			wallet[path] = current + amount
+		}
+		wallets[update.UserID] = wallet
+
+		results = append(results, &runtime.WalletUpdateResult{
+			UserID:   update.UserID,
+			Updated:  maps.Clone(wallet),
+			Previous: previous,
+		})
+		if updateLedger {
+			metadata := update.Metadata
+			if metadata == nil {
+				metadata = make(map[string]interface{})
+			}
+			ledger = append(ledger, &walletLedgerItem{
+				id:         generateID(),
+				userID:     update.UserID,
+				createTime: now,
+				updateTime: now,
+				changeset:  maps.Clone(update.Changeset),
+				metadata:   maps.Clone(metadata),
+			})
+		}
+	}
+
+	return results, func() {
+		for userID, wallet := range wallets {
+			n.accounts[userID].wallet = wallet
+		}
+		n.walletLedger = append(n.walletLedger, ledger...)
+	}, nil
+}
+
+func (n *NakamaModule) WalletLedgerUpdate(ctx context.Context, itemID string, metadata map[string]interface{}) (runtime.WalletLedgerItem, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, item := range n.walletLedger {
+		if item.id == itemID {
+			item.metadata = maps.Clone(metadata)
+			item.updateTime = n.Now().Unix()
+			return item.clone(), nil
+		}
+	}
+	return nil, errors.New("wallet ledger item not found")
+}
+
+// WalletLedgerList returns the ledger items of a user in the order they were created.
+func (n *NakamaModule) WalletLedgerList(ctx context.Context, userID string, limit int, cursor string) ([]runtime.WalletLedgerItem, string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	items := make([]*walletLedgerItem, 0)
+	for _, item := range n.walletLedger {
+		if item.userID == userID {
+			items = append(items, item)
+		}
+	}
+
+	start, end, next, err := paginate(cursor, limit, len(items))
+	if err != nil {
+		return nil, "", runtime.ErrWalletLedgerInvalidCursor
+	}
+	page := make([]runtime.WalletLedgerItem, 0, end-start)
+	for _, item := range items[start:end] {
+		page = append(page, item.clone())
+	}
+	return page, next, nil
+}